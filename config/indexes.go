@@ -0,0 +1,130 @@
+package config
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EnsureIndexes → bikin index yang dibutuhkan aplikasi (aman dipanggil berulang)
+func EnsureIndexes(client *mongo.Client, db string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := map[string][]mongo.IndexModel{
+		"booking": {
+			{
+				// record locator unik, sparse biar booking lama (tanpa locator) gak bentrok
+				Keys:    bson.D{{Key: "recordLocator", Value: 1}},
+				Options: options.Index().SetUnique(true).SetSparse(true),
+			},
+		},
+		"flights": {
+			{
+				// filter ?carrier= di list flight
+				Keys: bson.D{{Key: "carrierCode", Value: 1}, {Key: "departureTime", Value: 1}},
+			},
+			{
+				// satu flight per nomor penerbangan per tanggal, cuma buat flight hasil jadwal
+				Keys: bson.D{{Key: "flightNumber", Value: 1}, {Key: "serviceDate", Value: 1}},
+				Options: options.Index().SetUnique(true).
+					SetPartialFilterExpression(bson.M{"serviceDate": bson.M{"$exists": true}}),
+			},
+		},
+		"aircraft": {
+			{
+				Keys:    bson.D{{Key: "typeCode", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+		},
+		"airports": {
+			{
+				// IATA code unik, jadi key lookup waktu bikin flight
+				Keys:    bson.D{{Key: "code", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+		},
+		"airlines": {
+			{
+				Keys:    bson.D{{Key: "iata", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				// ICAO opsional
+				Keys:    bson.D{{Key: "icao", Value: 1}},
+				Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"icao": bson.M{"$gt": ""}}),
+			},
+		},
+		"waitlist": {
+			{
+				// antrian per flight + class, diurutkan FIFO
+				Keys: bson.D{{Key: "flightId", Value: 1}, {Key: "class", Value: 1}, {Key: "status", Value: 1}, {Key: "createdAt", Value: 1}},
+			},
+		},
+		"notifications": {
+			{
+				// antrian worker: pending yang sudah jatuh tempo
+				Keys: bson.D{{Key: "status", Value: 1}, {Key: "nextAttemptAt", Value: 1}},
+			},
+			{
+				// dedup event outbox yang dikirim ulang relay: satu per event per booking per channel
+				Keys:    bson.D{{Key: "eventId", Value: 1}, {Key: "userId", Value: 1}, {Key: "bookingId", Value: 1}, {Key: "channel", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+		},
+		"outbox": {
+			{
+				// relay: pending urut waktu dibuat
+				Keys: bson.D{{Key: "status", Value: 1}, {Key: "createdAt", Value: 1}},
+			},
+		},
+		"webhook_deliveries": {
+			{
+				// antrian worker
+				Keys: bson.D{{Key: "status", Value: 1}, {Key: "nextAttemptAt", Value: 1}},
+			},
+			{
+				// satu delivery per event per subscription, walau event dikirim ulang relay
+				Keys:    bson.D{{Key: "eventId", Value: 1}, {Key: "subscriptionId", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				// log delivery per subscription, terbaru duluan
+				Keys: bson.D{{Key: "subscriptionId", Value: 1}, {Key: "createdAt", Value: -1}},
+			},
+		},
+		"audit_logs": {
+			{
+				// query admin: per actor / per entity, terbaru duluan
+				Keys: bson.D{{Key: "actorId", Value: 1}, {Key: "at", Value: -1}},
+			},
+			{
+				Keys: bson.D{{Key: "entity", Value: 1}, {Key: "entityId", Value: 1}, {Key: "at", Value: -1}},
+			},
+			{
+				Keys: bson.D{{Key: "at", Value: -1}},
+			},
+		},
+		"idempotency_keys": {
+			{
+				Keys:    bson.D{{Key: "userId", Value: 1}, {Key: "key", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				// TTL: key dihapus Mongo otomatis setelah IdempotencyTTL
+				Keys:    bson.D{{Key: "createdAt", Value: 1}},
+				Options: options.Index().SetExpireAfterSeconds(int32(IdempotencyTTL().Seconds())),
+			},
+		},
+	}
+
+	for collection, idx := range indexes {
+		if _, err := GetCollection(client, db, collection).Indexes().CreateMany(ctx, idx); err != nil {
+			log.Fatalf("Error create index %s: %v", collection, err)
+		}
+	}
+}
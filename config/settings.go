@@ -0,0 +1,178 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HoldTTL → berapa lama kursi di-hold sebelum booking harus dikonfirmasi
+func HoldTTL() time.Duration {
+	return getDuration("holdTTL", 15*time.Minute)
+}
+
+// HoldSweepInterval → seberapa sering sweeper ngecek hold yang expired
+func HoldSweepInterval() time.Duration {
+	return getDuration("holdSweepInterval", time.Minute)
+}
+
+// WaitlistClaimWindow → waktu buat user waitlist nge-claim kursi yang ditawarkan
+func WaitlistClaimWindow() time.Duration {
+	return getDuration("waitlistClaimWindow", 30*time.Minute)
+}
+
+// IdempotencyTTL → berapa lama Idempotency-Key disimpan
+func IdempotencyTTL() time.Duration {
+	return getDuration("idempotencyTTL", 24*time.Hour)
+}
+
+// ScheduleHorizon → seberapa jauh ke depan flight dari jadwal rutin di-generate
+func ScheduleHorizon() time.Duration {
+	return getDuration("scheduleHorizon", 60*24*time.Hour)
+}
+
+// ScheduleGenerateInterval → seberapa sering generator jadwal jalan
+func ScheduleGenerateInterval() time.Duration {
+	return getDuration("scheduleGenerateInterval", 6*time.Hour)
+}
+
+// ReaccommodationWindow → flight pengganti boleh berangkat paling lambat segini setelah jadwal flight yang dibatalkan
+func ReaccommodationWindow() time.Duration {
+	return getDuration("reaccommodationWindow", 72*time.Hour)
+}
+
+// MinConnectionTime → waktu transit minimum default, kalau bandara belum punya minConnectionMinutes sendiri
+func MinConnectionTime() time.Duration {
+	return getDuration("minConnectionTime", 45*time.Minute)
+}
+
+// MaxTravelTime → batas total waktu perjalanan itinerary connecting (berangkat pertama → tiba terakhir)
+func MaxTravelTime() time.Duration {
+	return getDuration("maxTravelTime", 24*time.Hour)
+}
+
+// NotificationChannels → channel notifikasi yang aktif, dipisah koma (log, file, email)
+func NotificationChannels() []string {
+	value := os.Getenv("notificationChannels")
+	if value == "" {
+		return []string{"log"}
+	}
+	var channels []string
+	for _, channel := range strings.Split(value, ",") {
+		if channel = strings.TrimSpace(channel); channel != "" {
+			channels = append(channels, channel)
+		}
+	}
+	return channels
+}
+
+// NotificationFile → file tujuan channel "file"
+func NotificationFile() string {
+	if v := os.Getenv("notificationFile"); v != "" {
+		return v
+	}
+	return "notifications.log"
+}
+
+// NotificationTemplateDir → folder override template notifikasi, kosong = template bawaan
+func NotificationTemplateDir() string {
+	return os.Getenv("notificationTemplateDir")
+}
+
+// NotificationLocale → bahasa default notifikasi kalau user belum set locale
+func NotificationLocale() string {
+	if v := os.Getenv("notificationLocale"); v != "" {
+		return v
+	}
+	return "id"
+}
+
+// NotificationMaxAttempts → berapa kali kirim ulang sebelum notifikasi dianggap gagal
+func NotificationMaxAttempts() int {
+	value, err := strconv.Atoi(os.Getenv("notificationMaxAttempts"))
+	if err != nil || value <= 0 {
+		return 5
+	}
+	return value
+}
+
+// NotificationInterval → seberapa sering antrian notifikasi diproses
+func NotificationInterval() time.Duration {
+	return getDuration("notificationInterval", 30*time.Second)
+}
+
+// WebhookMaxAttempts → berapa kali webhook dikirim ulang sebelum masuk dead letter
+func WebhookMaxAttempts() int {
+	value, err := strconv.Atoi(os.Getenv("webhookMaxAttempts"))
+	if err != nil || value <= 0 {
+		return 8
+	}
+	return value
+}
+
+// WebhookInterval → seberapa sering antrian webhook diproses
+func WebhookInterval() time.Duration {
+	return getDuration("webhookInterval", 15*time.Second)
+}
+
+// WebhookTimeout → batas waktu satu request ke endpoint subscriber
+func WebhookTimeout() time.Duration {
+	return getDuration("webhookTimeout", 10*time.Second)
+}
+
+// OutboxInterval → seberapa sering relay outbox publish event baru
+func OutboxInterval() time.Duration {
+	return getDuration("outboxInterval", 2*time.Second)
+}
+
+// OutboxMaxAttempts → berapa kali event outbox dicoba publish sebelum ditandai failed
+func OutboxMaxAttempts() int {
+	value, err := strconv.Atoi(os.Getenv("outboxMaxAttempts"))
+	if err != nil || value <= 0 {
+		return 10
+	}
+	return value
+}
+
+func getDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return fallback
+	}
+	return d
+}
+
+// Currency → mata uang default buat payment
+func Currency() string {
+	if v := os.Getenv("currency"); v != "" {
+		return v
+	}
+	return "IDR"
+}
+
+// FlightChangeFee → biaya tetap ganti flight (di luar selisih harga)
+func FlightChangeFee() float64 {
+	return getFloat("flightChangeFee", 0)
+}
+
+func getFloat(key string, fallback float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f < 0 {
+		return fallback
+	}
+	return f
+}
+
+// CancellationPolicyFile → path file JSON policy cancel, kosong = pakai default
+func CancellationPolicyFile() string {
+	return os.Getenv("cancellationPolicyFile")
+}
@@ -0,0 +1,193 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"airplane_booking_go/models"
+	"airplane_booking_go/services"
+	"airplane_booking_go/utils"
+	"airplane_booking_go/validations"
+)
+
+type AircraftController struct {
+	AircraftCollection *mongo.Collection
+}
+
+func NewAircraftController(aircraftCollection *mongo.Collection) *AircraftController {
+	return &AircraftController{AircraftCollection: aircraftCollection}
+}
+
+// CreateAircraft → daftarkan tipe pesawat baru beserta template kabinnya (admin)
+func (ac *AircraftController) CreateAircraft(c *gin.Context) {
+	var req validations.AircraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// pastikan template bisa di-generate jadi seat map
+	if _, err := services.GenerateSeats(req.Layout); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	aircraft := models.Aircraft{
+		ID:           primitive.NewObjectID(),
+		TypeCode:     strings.ToUpper(req.TypeCode),
+		Name:         req.Name,
+		Manufacturer: req.Manufacturer,
+		Layout:       req.Layout,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := ac.AircraftCollection.InsertOne(ctx, aircraft); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "aircraft type already exists"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to insert aircraft"})
+		return
+	}
+
+	utils.SetAuditTarget(c, aircraft.TypeCode)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"code":     201,
+		"status":   "Created",
+		"message":  "aircraft created",
+		"aircraft": aircraft,
+	})
+}
+
+// GetAllAircraft → list semua tipe pesawat
+func (ac *AircraftController) GetAllAircraft(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := ac.AircraftCollection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"typeCode": 1}))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch aircraft"})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	aircraft := []models.Aircraft{}
+	if err := cursor.All(ctx, &aircraft); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to decode aircraft"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":     200,
+		"status":   "OK",
+		"message":  "success get aircraft",
+		"aircraft": aircraft,
+	})
+}
+
+// GetAircraft → detail satu tipe pesawat + preview seat map
+func (ac *AircraftController) GetAircraft(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	aircraft, ok := ac.findAircraft(ctx, c, c.Param("code"))
+	if !ok {
+		return
+	}
+
+	seats, _ := services.GenerateSeats(aircraft.Layout)
+	c.JSON(http.StatusOK, gin.H{
+		"code":       200,
+		"status":     "OK",
+		"message":    "success get aircraft",
+		"aircraft":   aircraft,
+		"totalSeats": len(seats),
+		"seats":      seats,
+	})
+}
+
+// UpdateAircraft → ubah nama / template kabin. Flight yang sudah ada gak ikut berubah,
+// pakai PUT /flights/:id/aircraft buat ganti seat map flight.
+func (ac *AircraftController) UpdateAircraft(c *gin.Context) {
+	var req validations.AircraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !strings.EqualFold(req.TypeCode, c.Param("code")) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "typeCode cannot be changed"})
+		return
+	}
+	if _, err := services.GenerateSeats(req.Layout); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := ac.AircraftCollection.UpdateOne(ctx,
+		bson.M{"typeCode": strings.ToUpper(c.Param("code"))},
+		bson.M{"$set": bson.M{
+			"name":         req.Name,
+			"manufacturer": req.Manufacturer,
+			"layout":       req.Layout,
+			"updatedAt":    time.Now(),
+		}},
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update aircraft"})
+		return
+	}
+	if result.MatchedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "aircraft not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "aircraft updated successfully"})
+}
+
+// DeleteAircraft → hapus tipe pesawat, flight lama tetap punya salinan layout sendiri
+func (ac *AircraftController) DeleteAircraft(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := ac.AircraftCollection.DeleteOne(ctx, bson.M{"typeCode": strings.ToUpper(c.Param("code"))})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete aircraft"})
+		return
+	}
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "aircraft not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "aircraft deleted successfully"})
+}
+
+func (ac *AircraftController) findAircraft(ctx context.Context, c *gin.Context, typeCode string) (models.Aircraft, bool) {
+	var aircraft models.Aircraft
+	err := ac.AircraftCollection.FindOne(ctx, bson.M{"typeCode": strings.ToUpper(typeCode)}).Decode(&aircraft)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "aircraft not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch aircraft"})
+		}
+		return models.Aircraft{}, false
+	}
+	return aircraft, true
+}
@@ -0,0 +1,204 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"airplane_booking_go/models"
+	"airplane_booking_go/services"
+	"airplane_booking_go/utils"
+	"airplane_booking_go/validations"
+)
+
+type AirlineController struct {
+	AirlineCollection *mongo.Collection
+}
+
+func NewAirlineController(airlineCollection *mongo.Collection) *AirlineController {
+	return &AirlineController{AirlineCollection: airlineCollection}
+}
+
+// CreateAirline → tambah data referensi maskapai (admin)
+func (ac *AirlineController) CreateAirline(c *gin.Context) {
+	var req validations.AirlineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	airline := airlineFromRequest(req)
+	airline.ID = primitive.NewObjectID()
+	airline.CreatedAt = time.Now()
+	airline.UpdatedAt = time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := ac.AirlineCollection.InsertOne(ctx, airline); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "airline with this IATA / ICAO code already exists"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to insert airline"})
+		return
+	}
+
+	utils.SetAuditTarget(c, airline.IATA)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"code":    201,
+		"status":  "Created",
+		"message": "airline created",
+		"airline": airline,
+	})
+}
+
+// GetAllAirlines → list semua maskapai
+func (ac *AirlineController) GetAllAirlines(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := ac.AirlineCollection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"iata": 1}))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch airlines"})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	airlines := []models.Airline{}
+	if err := cursor.All(ctx, &airlines); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to decode airlines"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":     200,
+		"status":   "OK",
+		"message":  "success get airlines",
+		"airlines": airlines,
+	})
+}
+
+// GetAirline → detail maskapai by IATA / ICAO code
+func (ac *AirlineController) GetAirline(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	airline, err := services.ResolveAirline(ctx, ac.AirlineCollection, c.Param("code"))
+	if err != nil {
+		if errors.Is(err, services.ErrUnknownAirline) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "airline not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch airline"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"status":  "OK",
+		"message": "success get airline",
+		"airline": airline,
+	})
+}
+
+// UpdateAirline → ubah nama / logo / bagasi. Flight yang sudah ada tetap pakai snapshot nama + logo lamanya.
+func (ac *AirlineController) UpdateAirline(c *gin.Context) {
+	var req validations.AirlineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !strings.EqualFold(req.IATA, c.Param("code")) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "iata cannot be changed"})
+		return
+	}
+
+	airline := airlineFromRequest(req)
+	set := bson.M{
+		"name":            airline.Name,
+		"logoUrl":         airline.LogoURL,
+		"baggagePolicies": airline.BaggagePolicies,
+		"updatedAt":       time.Now(),
+	}
+	update := bson.M{"$set": set}
+	if airline.ICAO != "" {
+		set["icao"] = airline.ICAO
+	} else {
+		update["$unset"] = bson.M{"icao": ""}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := ac.AirlineCollection.UpdateOne(ctx, bson.M{"iata": airline.IATA}, update)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "ICAO code already used by another airline"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update airline"})
+		return
+	}
+	if result.MatchedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "airline not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "airline updated successfully"})
+}
+
+// DeleteAirline → hapus maskapai, flight lama tetap punya snapshot-nya
+func (ac *AirlineController) DeleteAirline(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := ac.AirlineCollection.DeleteOne(ctx, bson.M{"iata": strings.ToUpper(c.Param("code"))})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete airline"})
+		return
+	}
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "airline not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "airline deleted successfully"})
+}
+
+func airlineFromRequest(req validations.AirlineRequest) models.Airline {
+	policies := make([]models.BaggagePolicy, 0, len(req.BaggagePolicies))
+	for _, p := range req.BaggagePolicies {
+		policies = append(policies, models.BaggagePolicy{
+			Class:           p.Class,
+			CabinKg:         p.CabinKg,
+			CheckedPieces:   p.CheckedPieces,
+			CheckedKgPerBag: p.CheckedKgPerBag,
+		})
+	}
+	return models.Airline{
+		IATA:            strings.ToUpper(req.IATA),
+		ICAO:            strings.ToUpper(req.ICAO),
+		Name:            req.Name,
+		LogoURL:         req.LogoURL,
+		BaggagePolicies: policies,
+	}
+}
+
+// writeAirlineError → maskapai gak dikenal / nomor penerbangan salah = salah request, selain itu error server
+func writeAirlineError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrUnknownAirline) || errors.Is(err, services.ErrInvalidFlightNumber) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch airline"})
+}
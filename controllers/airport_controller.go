@@ -0,0 +1,275 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"airplane_booking_go/models"
+	"airplane_booking_go/services"
+	"airplane_booking_go/utils"
+	"airplane_booking_go/validations"
+)
+
+type AirportController struct {
+	AirportCollection *mongo.Collection
+}
+
+func NewAirportController(airportCollection *mongo.Collection) *AirportController {
+	return &AirportController{AirportCollection: airportCollection}
+}
+
+// CreateAirport → tambah data referensi bandara (admin)
+func (ac *AirportController) CreateAirport(c *gin.Context) {
+	var req validations.AirportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	airport := airportFromRequest(req)
+	if err := services.ValidateAirport(airport.Airport); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	airport.ID = primitive.NewObjectID()
+	airport.CreatedAt = time.Now()
+	airport.UpdatedAt = time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := ac.AirportCollection.InsertOne(ctx, airport); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "airport already exists"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to insert airport"})
+		return
+	}
+
+	utils.SetAuditTarget(c, airport.Code)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"code":    201,
+		"status":  "Created",
+		"message": "airport created",
+		"airport": airport,
+	})
+}
+
+// GetAllAirports → list bandara, filter: q (code / nama / kota), country
+func (ac *AirportController) GetAllAirports(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pagination := utils.GetPagination(c)
+
+	filter := bson.M{}
+	if q := strings.TrimSpace(c.Query("q")); q != "" {
+		pattern := regexp.QuoteMeta(q)
+		filter["$or"] = []bson.M{
+			{"code": strings.ToUpper(q)},
+			{"name": bson.M{"$regex": pattern, "$options": "i"}},
+			{"city": bson.M{"$regex": pattern, "$options": "i"}},
+		}
+	}
+	if country := c.Query("country"); country != "" {
+		filter["country"] = country
+	}
+
+	total, err := ac.AirportCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count documents"})
+		return
+	}
+
+	cursor, err := ac.AirportCollection.Find(ctx, filter,
+		options.Find().
+			SetSkip(int64(pagination.Skip)).
+			SetLimit(int64(pagination.Limit)).
+			SetSort(bson.M{"code": 1}),
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch airports"})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	airports := []models.AirportRecord{}
+	if err := cursor.All(ctx, &airports); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to decode airports"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":     200,
+		"status":   "OK",
+		"message":  "success get airports",
+		"page":     pagination.Page,
+		"limit":    pagination.Limit,
+		"total":    total,
+		"airports": airports,
+	})
+}
+
+// GetAirport → detail satu bandara by IATA code
+func (ac *AirportController) GetAirport(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var airport models.AirportRecord
+	err := ac.AirportCollection.FindOne(ctx, bson.M{"code": strings.ToUpper(c.Param("code"))}).Decode(&airport)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "airport not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch airport"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"status":  "OK",
+		"message": "success get airport",
+		"airport": airport,
+	})
+}
+
+// UpdateAirport → ubah data bandara. Flight yang sudah ada tetap pakai snapshot lamanya.
+func (ac *AirportController) UpdateAirport(c *gin.Context) {
+	var req validations.AirportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !strings.EqualFold(req.Code, c.Param("code")) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "code cannot be changed"})
+		return
+	}
+
+	airport := airportFromRequest(req)
+	if err := services.ValidateAirport(airport.Airport); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := ac.AirportCollection.UpdateOne(ctx,
+		bson.M{"code": airport.Code},
+		bson.M{"$set": bson.M{
+			"name":                 airport.Name,
+			"city":                 airport.City,
+			"country":              airport.Country,
+			"timeZone":             airport.TimeZone,
+			"latitude":             airport.Latitude,
+			"longitude":            airport.Longitude,
+			"icao":                 airport.ICAO,
+			"type":                 airport.Type,
+			"minConnectionMinutes": airport.MinConnectionMinutes,
+			"updatedAt":            time.Now(),
+		}},
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update airport"})
+		return
+	}
+	if result.MatchedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "airport not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "airport updated successfully"})
+}
+
+// DeleteAirport → hapus bandara, flight lama tetap punya snapshot-nya
+func (ac *AirportController) DeleteAirport(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := ac.AirportCollection.DeleteOne(ctx, bson.M{"code": strings.ToUpper(c.Param("code"))})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete airport"})
+		return
+	}
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "airport not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "airport deleted successfully"})
+}
+
+// ImportAirports → bulk upsert dari CSV OurAirports, kirim sebagai multipart field "file" atau body text/csv (admin)
+func (ac *AirportController) ImportAirports(c *gin.Context) {
+	var source io.Reader = c.Request.Body
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		file, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+			return
+		}
+		f, err := file.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "failed to open file"})
+			return
+		}
+		defer f.Close()
+		source = f
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	result, err := services.ImportAirportsCSV(ctx, ac.AirportCollection, source)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "result": result})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"status":  "OK",
+		"message": "airports imported",
+		"result":  result,
+	})
+}
+
+func airportFromRequest(req validations.AirportRequest) models.AirportRecord {
+	return models.AirportRecord{
+		Airport: models.Airport{
+			Code:      strings.ToUpper(req.Code),
+			Name:      req.Name,
+			City:      req.City,
+			Country:   req.Country,
+			TimeZone:  req.TimeZone,
+			Latitude:  req.Latitude,
+			Longitude: req.Longitude,
+		},
+		ICAO:                 strings.ToUpper(req.ICAO),
+		Type:                 req.Type,
+		MinConnectionMinutes: req.MinConnectionMinutes,
+	}
+}
+
+// writeAirportError → bandara gak dikenal = salah request, selain itu error server
+func writeAirportError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrUnknownAirport) || errors.Is(err, services.ErrSameAirport) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch airport"})
+}
@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"airplane_booking_go/models"
+	"airplane_booking_go/utils"
+)
+
+type AuditController struct {
+	AuditCollection *mongo.Collection
+}
+
+func NewAuditController(auditColl *mongo.Collection) *AuditController {
+	return &AuditController{AuditCollection: auditColl}
+}
+
+// GetAuditLogs → list audit log (admin), filter: actor, entity, entityId, action, from/to (RFC3339 / YYYY-MM-DD)
+func (ac *AuditController) GetAuditLogs(c *gin.Context) {
+	filter := bson.M{}
+	if actor := c.Query("actor"); actor != "" {
+		filter["actorId"] = actor
+	}
+	if entity := c.Query("entity"); entity != "" {
+		filter["entity"] = entity
+	}
+	if entityID := c.Query("entityId"); entityID != "" {
+		filter["entityId"] = entityID
+	}
+	if action := c.Query("action"); action != "" {
+		filter["action"] = action
+	}
+
+	at := bson.M{}
+	if from := c.Query("from"); from != "" {
+		t, err := parseAuditTime(from, false)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from, use RFC3339 or YYYY-MM-DD"})
+			return
+		}
+		at["$gte"] = t
+	}
+	if to := c.Query("to"); to != "" {
+		t, err := parseAuditTime(to, true)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to, use RFC3339 or YYYY-MM-DD"})
+			return
+		}
+		at["$lt"] = t
+	}
+	if len(at) > 0 {
+		filter["at"] = at
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pagination := utils.GetPagination(c)
+
+	total, err := ac.AuditCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count documents"})
+		return
+	}
+
+	cursor, err := ac.AuditCollection.Find(ctx, filter,
+		options.Find().
+			SetSkip(int64(pagination.Skip)).
+			SetLimit(int64(pagination.Limit)).
+			SetSort(bson.D{{Key: "at", Value: -1}}),
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch audit logs"})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	logs := []models.AuditLog{}
+	if err := cursor.All(ctx, &logs); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to decode audit logs"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"status":  "OK",
+		"message": "success get audit logs",
+		"page":    pagination.Page,
+		"limit":   pagination.Limit,
+		"total":   total,
+		"logs":    logs,
+	})
+}
+
+// parseAuditTime → RFC3339 atau tanggal saja; tanggal di "to" dihitung sampai akhir hari itu
+func parseAuditTime(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
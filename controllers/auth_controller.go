@@ -29,6 +29,7 @@ type RegisterRequest struct {
 	Name     string `json:"name" binding:"required"`
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=6"`
+	Locale   string `json:"locale" binding:"omitempty,oneof=en id"` // bahasa notifikasi, default notificationLocale
 }
 
 type LoginRequest struct {
@@ -80,6 +81,7 @@ func (uc *UserController) Register(c *gin.Context) {
 		Email:     req.Email,
 		Password:  string(hashedPassword), // hashed password
 		Role:      "user",                 // default role
+		Locale:    req.Locale,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -0,0 +1,243 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"airplane_booking_go/config"
+	"airplane_booking_go/models"
+	"airplane_booking_go/services"
+	"airplane_booking_go/utils"
+	"airplane_booking_go/validations"
+)
+
+// ChangeSeats godoc
+// @Summary Change seats on a confirmed booking
+// @Description Swap some seats of a confirmed booking for other available seats on the same flight, charging or refunding the fare difference
+// @Tags booking
+// @Accept json
+// @Produce json
+// @Param id path string true "Booking ID"
+// @Param change body validations.ChangeSeatsRequest true "Seat change request body"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 402 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /book/{id}/seats [put]
+func (bc *BookingController) ChangeSeats(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req validations.ChangeSeatsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	booking, ok := bc.findUserBooking(ctx, c, userID)
+	if !ok {
+		return
+	}
+	if booking.Status != models.BookingStatusConfirmed {
+		c.JSON(http.StatusConflict, gin.H{"error": "only confirmed bookings can change seats"})
+		return
+	}
+	if booking.IsItinerary() {
+		c.JSON(http.StatusConflict, gin.H{"error": "multi-segment bookings cannot be changed, cancel and rebook instead"})
+		return
+	}
+
+	var flight models.Flight
+	if err := bc.FlightCollection.FindOne(ctx, bson.M{"_id": booking.FlightID}).Decode(&flight); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch flight"})
+		return
+	}
+
+	swaps := make([]models.SeatSwap, 0, len(req.Changes))
+	for _, change := range req.Changes {
+		swaps = append(swaps, models.SeatSwap{From: change.From, To: change.To})
+	}
+
+	plan, err := services.PlanSeatChange(booking, flight, swaps)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	change := models.BookingChange{
+		Type:           "seat_change",
+		FromFlightID:   booking.FlightID,
+		ToFlightID:     booking.FlightID,
+		SeatSwaps:      plan.Swaps,
+		FareDifference: plan.FareDifference,
+		Actor:          userID.Hex(),
+		At:             time.Now(),
+	}
+
+	settlement, err := services.SettleChange(ctx, bc.PaymentService, booking, plan.FareDifference, req.PaymentToken, "seat change",
+		func(paymentID *primitive.ObjectID) error {
+			change.PaymentID = paymentID
+			return services.ApplySeatChange(ctx, bc.BookingCollection, bc.FlightCollection, booking, plan, change)
+		})
+	if err != nil {
+		writeChangeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "seats changed",
+		"change":     change,
+		"settlement": settlement,
+	})
+}
+
+// ChangeFlight godoc
+// @Summary Move a confirmed booking to another flight
+// @Description Move all passengers to seats on another flight of the same route in one transaction, charging the fare difference plus change fee or refunding the difference
+// @Tags booking
+// @Accept json
+// @Produce json
+// @Param id path string true "Booking ID"
+// @Param change body validations.ChangeFlightRequest true "Flight change request body"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 402 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /book/{id}/change-flight [post]
+func (bc *BookingController) ChangeFlight(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req validations.ChangeFlightRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	newFlightID, err := primitive.ObjectIDFromHex(req.FlightID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid flightId"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	booking, ok := bc.findUserBooking(ctx, c, userID)
+	if !ok {
+		return
+	}
+	if booking.Status != models.BookingStatusConfirmed {
+		c.JSON(http.StatusConflict, gin.H{"error": "only confirmed bookings can change flight"})
+		return
+	}
+	if booking.IsItinerary() {
+		c.JSON(http.StatusConflict, gin.H{"error": "multi-segment bookings cannot be changed, cancel and rebook instead"})
+		return
+	}
+
+	var oldFlight, newFlight models.Flight
+	if err := bc.FlightCollection.FindOne(ctx, bson.M{"_id": booking.FlightID}).Decode(&oldFlight); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch flight"})
+		return
+	}
+	if err := bc.FlightCollection.FindOne(ctx, bson.M{"_id": newFlightID}).Decode(&newFlight); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "flight not found"})
+		return
+	}
+
+	if err := services.EnsureNotDeparted(oldFlight); err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	plan, err := services.PlanFlightChange(booking, oldFlight, newFlight, req.SeatNumbers)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	swaps := make([]models.SeatSwap, 0, len(booking.Seats))
+	for i, seat := range booking.Seats {
+		swaps = append(swaps, models.SeatSwap{From: seat.Number, To: plan.NewSeats[i].Number})
+	}
+
+	change := models.BookingChange{
+		Type:           "flight_change",
+		FromFlightID:   oldFlight.ID,
+		ToFlightID:     newFlight.ID,
+		SeatSwaps:      swaps,
+		FareDifference: plan.FareDifference,
+		ChangeFee:      config.FlightChangeFee(),
+		Actor:          userID.Hex(),
+		At:             time.Now(),
+	}
+
+	amountDue := change.FareDifference + change.ChangeFee
+	settlement, err := services.SettleChange(ctx, bc.PaymentService, booking, amountDue, req.PaymentToken, "flight change",
+		func(paymentID *primitive.ObjectID) error {
+			change.PaymentID = paymentID
+			return services.ApplyFlightChange(ctx, bc.BookingCollection, bc.FlightCollection, booking, plan, change)
+		})
+	if err != nil {
+		writeChangeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "flight changed",
+		"change":     change,
+		"settlement": settlement,
+	})
+}
+
+// findUserBooking → ambil booking milik user dari param :id, tulis response error kalau gagal
+func (bc *BookingController) findUserBooking(ctx context.Context, c *gin.Context, userID primitive.ObjectID) (models.Booking, bool) {
+	var booking models.Booking
+
+	bookingObjID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid booking id"})
+		return booking, false
+	}
+
+	err = bc.BookingCollection.FindOne(ctx, bson.M{"_id": bookingObjID, "userId": userID}).Decode(&booking)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "booking not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch booking"})
+		}
+		return booking, false
+	}
+	return booking, true
+}
+
+// writeChangeError → mapping error dari services ke status HTTP
+func writeChangeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrPaymentRequired):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrPaymentFailed):
+		c.JSON(http.StatusPaymentRequired, gin.H{"error": err.Error()})
+	default:
+		// kursi keburu diambil / booking berubah di tengah jalan
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	}
+}
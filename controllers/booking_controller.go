@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -13,20 +14,30 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
+	"airplane_booking_go/config"
 	"airplane_booking_go/models"
+	"airplane_booking_go/services"
 	"airplane_booking_go/utils"
 	"airplane_booking_go/validations"
 )
 
 type BookingController struct {
-	BookingCollection *mongo.Collection
-	FlightCollection  *mongo.Collection
+	BookingCollection    *mongo.Collection
+	FlightCollection     *mongo.Collection
+	AirportCollection    *mongo.Collection
+	PaymentService       *services.PaymentService
+	CancellationPolicies *services.CancellationPolicies
+	Waitlist             *services.Waitlist
 }
 
-func NewBookingController(bookingColl, flightColl *mongo.Collection) *BookingController {
+func NewBookingController(bookingColl, flightColl, airportColl *mongo.Collection, paymentService *services.PaymentService, policies *services.CancellationPolicies, waitlist *services.Waitlist) *BookingController {
 	return &BookingController{
-		BookingCollection: bookingColl,
-		FlightCollection:  flightColl,
+		BookingCollection:    bookingColl,
+		FlightCollection:     flightColl,
+		AirportCollection:    airportColl,
+		PaymentService:       paymentService,
+		CancellationPolicies: policies,
+		Waitlist:             waitlist,
 	}
 }
 
@@ -50,8 +61,9 @@ func (fc *FlightController) GetFlightByID(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "flight not found"})
 		return
 	}
+	flight.Status = flight.CurrentStatus()
 
-	c.JSON(http.StatusOK, flight)
+	c.JSON(http.StatusOK, flight.WithLocalTimes())
 }
 // CreateBooking godoc
 // @Summary Create a new booking
@@ -73,8 +85,8 @@ func (bc *BookingController) CreateBooking(c *gin.Context) {
 	}
 
 	// fetch userID from context
-	userID, exists := c.Get("userId")
-	if !exists {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
@@ -85,6 +97,17 @@ func (bc *BookingController) CreateBooking(c *gin.Context) {
 		return
 	}
 
+	if len(req.SeatNumbers) == 0 && req.Class == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "either seatNumbers or class is required"})
+		return
+	}
+
+	passengers, err := validations.ValidatePassengers(req.Passengers, req.SeatNumbers)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	ctx := context.Background()
 	session, err := bc.FlightCollection.Database().Client().StartSession()
 	if err != nil {
@@ -102,75 +125,76 @@ func (bc *BookingController) CreateBooking(c *gin.Context) {
 		if err := bc.FlightCollection.FindOne(sessCtx, bson.M{"_id": flightObjID}).Decode(&flight); err != nil {
 			return nil, fmt.Errorf("flight not found")
 		}
+		if err := services.EnsureBookable(flight); err != nil {
+			return nil, err
+		}
 
-		// check seats avaiable + count total
-		var selectedSeats []models.Seat
-		totalPrice := 0.0
-		for _, seatNum := range req.SeatNumbers {
-			found := false
-			for _, seat := range flight.Seats {
-				if seat.Number == seatNum {
-					found = true
-					if !seat.IsAvailable {
-						return nil, fmt.Errorf("seat %s not available", seatNum)
-					}
-					selectedSeats = append(selectedSeats, seat)
-					totalPrice += seat.Price
-					break
-				}
+		// gak pilih kursi → server yang pilihkan (diutamakan berdampingan)
+		seatNumbers := req.SeatNumbers
+		if len(seatNumbers) == 0 {
+			assigned, err := services.AssignSeats(flight, req.Class, len(passengers))
+			if err != nil {
+				return nil, err
 			}
-			if !found {
-				return nil, fmt.Errorf("seat %s not found", seatNum)
+			seatNumbers = make([]string, 0, len(assigned))
+			for _, seat := range assigned {
+				seatNumbers = append(seatNumbers, seat.Number)
 			}
 		}
 
+		// check seats avaiable + count total
+		selectedSeats, totalPrice, err := services.SelectSeats(flight, seatNumbers)
+		if err != nil {
+			return nil, err
+		}
+
 		// update seats into unavailable (bulk update)
-		for _, seatNum := range req.SeatNumbers {
-			result, err := bc.FlightCollection.UpdateOne(
-				sessCtx,
-				bson.M{
-					"_id": flightObjID,
-					"seats": bson.M{
-						"$elemMatch": bson.M{"number": seatNum, "isAvailable": true},
-					},
-				},
-				bson.M{
-					"$set": bson.M{
-						"seats.$.isAvailable": false,
-						"updated_at":           time.Now(),
-					},
-				},
-			)
-			if err != nil || result.ModifiedCount == 0 {
-				return nil, fmt.Errorf("seat %s just got booked", seatNum)
-			}
+		if err := services.ClaimSeats(sessCtx, bc.FlightCollection, flightObjID, seatNumbers); err != nil {
+			return nil, err
 		}
 
-		// buat booking baru
-		booking = models.Booking{
-			ID:         primitive.NewObjectID(),
-			UserID:     userID.(primitive.ObjectID),
-			FlightID:   flightObjID,
-			Seats:      selectedSeats,
-			TotalPrice: totalPrice,
-			Status:     "confirmed", // nanti bisa diganti "pending" kalau ada pembayaran
-			CreatedAt:  time.Now(),
-			UpdatedAt:  time.Now(),
+		seatedPassengers := make([]models.Passenger, len(passengers))
+		copy(seatedPassengers, passengers)
+		for i := range seatedPassengers {
+			seatedPassengers[i].SeatNumber = seatNumbers[i]
 		}
 
+		recordLocator, err := services.NewRecordLocator(sessCtx, bc.BookingCollection)
+		if err != nil {
+			return nil, err
+		}
+
+		// buat booking baru, kalau belum dibayar sampai holdExpiresAt kursinya dilepas sweeper
+		booking = services.NewBooking(userID, recordLocator, req.Hold, config.HoldTTL())
+		booking.FlightID = flightObjID
+		booking.Seats = selectedSeats
+		booking.Passengers = seatedPassengers
+		booking.TotalPrice = totalPrice
+
 		if _, err := bc.BookingCollection.InsertOne(sessCtx, booking); err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return nil, err
+			}
 			return nil, fmt.Errorf("failed to insert booking: %v", err)
 		}
 
-		return nil, nil
+		return nil, services.RecordBookingCreated(sessCtx, bc.BookingCollection, booking, "booking")
 	}
 
-	// jalankan transaksi
-	if _, err = session.WithTransaction(ctx, callback); err != nil {
+	// jalankan transaksi, ulang kalau record locator bentrok di unique index
+	for attempt := 0; attempt < 3; attempt++ {
+		_, err = session.WithTransaction(ctx, callback)
+		if !mongo.IsDuplicateKeyError(err) {
+			break
+		}
+	}
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	utils.SetAuditTarget(c, booking.ID.Hex())
+
 	c.JSON(http.StatusCreated, gin.H{
 		"message": "booking created",
 		"booking": booking,
@@ -227,7 +251,7 @@ func (bc *BookingController) GetAllBookings(c *gin.Context) {
         UserID     primitive.ObjectID `bson:"userId" json:"userId"`
         FlightID   primitive.ObjectID `bson:"flightId" json:"flightId"`
         TotalPrice float64            `bson:"totalPrice" json:"totalPrice"`
-        Status     string             `bson:"status" json:"status"`
+        Status     models.BookingStatus `bson:"status" json:"status"`
         CreatedAt  time.Time          `bson:"createdAt" json:"createdAt"`
     }
 
@@ -241,8 +265,8 @@ func (bc *BookingController) GetAllBookings(c *gin.Context) {
 }
 
 func (bc *BookingController) GetUserBookings(c *gin.Context) {
-	userID, exists := c.Get("userId")
-	if !exists {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
@@ -252,7 +276,7 @@ func (bc *BookingController) GetUserBookings(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	filter := bson.M{"userId": userID.(primitive.ObjectID)}
+	filter := bson.M{"userId": userID}
 
 	// hitung total documents
 	total, err := bc.BookingCollection.CountDocuments(ctx, filter)
@@ -291,8 +315,8 @@ func (bc *BookingController) GetUserBookings(c *gin.Context) {
 }
 
 func (bc *BookingController) GetUserBookingDetail(c *gin.Context) {
-    userID, exists := c.Get("userId")
-    if !exists {
+    userID, err := utils.GetUserID(c)
+    if err != nil {
         c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
         return
     }
@@ -310,7 +334,7 @@ func (bc *BookingController) GetUserBookingDetail(c *gin.Context) {
     var booking models.Booking
     err = bc.BookingCollection.FindOne(ctx, bson.M{
         "_id":    bookingObjID,
-        "userId": userID.(primitive.ObjectID), // pastikan booking ini milik user
+        "userId": userID, // pastikan booking ini milik user
     }).Decode(&booking)
 
     if err != nil {
@@ -322,33 +346,214 @@ func (bc *BookingController) GetUserBookingDetail(c *gin.Context) {
         return
     }
 
-	var flight models.Flight
-	err = bc.FlightCollection.FindOne(ctx, bson.M{"_id": booking.FlightID}).Decode(&flight)
+	flights, err := services.LoadBookingFlights(ctx, bc.FlightCollection, booking)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch flight"})
 		return
 	}
+	data := bookingDetailData(booking, flights)
+    c.JSON(http.StatusOK, gin.H{
+        "status":  "OK",
+		"message":	"Success",
+        "data": data,
+    })
+}
+
+// bookingDetailData → bentuk response detail booking + ringkasan flight (dan segmen kalau itinerary)
+func bookingDetailData(booking models.Booking, flights map[primitive.ObjectID]models.Flight) gin.H {
 	data := gin.H{
 		"bookingId":   booking.ID,
+		"recordLocator": booking.RecordLocator,
 		"status":      booking.Status,
+		"holdExpiresAt": booking.HoldExpiresAt,
+		"paymentId":   booking.PaymentID,
+		"statusHistory": booking.StatusHistory,
 		"seats":       booking.Seats,
+		"passengers":  booking.Passengers,
 		"totalPrice":  booking.TotalPrice,
+		"refundAmount": booking.RefundAmount,
 		"bookedAt":    booking.CreatedAt,
-		"flight": gin.H{
-			"airline":       flight.Airline,
-			"flightNumber":  flight.FlightNumber,
-			"departure":     flight.Departure,
-			"arrival":       flight.Arrival,
-			"departureTime": flight.DepartureTime,
-			"arrivalTime":   flight.ArrivalTime,
-			"duration":      flight.Duration,
-		},
+		"flight":      flightSummary(flights[booking.FlightID]),
+	}
+
+	if booking.IsItinerary() {
+		segments := make([]gin.H, 0, len(booking.Segments))
+		for _, segment := range booking.Segments {
+			segments = append(segments, gin.H{
+				"flight":   flightSummary(flights[segment.FlightID]),
+				"seats":    segment.Seats,
+				"subTotal": segment.SubTotal,
+			})
+		}
+		data["segments"] = segments
+	}
+	return data
+}
+
+func flightSummary(flight models.Flight) gin.H {
+	return gin.H{
+		"airline":                flight.Airline,
+		"carrierCode":            flight.CarrierCode,
+		"airlineLogoUrl":         flight.AirlineLogoURL,
+		"flightNumber":           flight.FlightNumber,
+		"departure":              flight.Departure,
+		"arrival":                flight.Arrival,
+		"departureTime":          flight.DepartureTime,
+		"arrivalTime":            flight.ArrivalTime,
+		"departureLocalTime":     flight.Departure.LocalTime(flight.DepartureTime),
+		"arrivalLocalTime":       flight.Arrival.LocalTime(flight.ArrivalTime),
+		"duration":               flight.Duration,
+		"flightStatus":           flight.CurrentStatus(),
+		"estimatedDepartureTime": flight.EstimatedDepartureTime,
+		"estimatedArrivalTime":   flight.EstimatedArrivalTime,
 	}
-    c.JSON(http.StatusOK, gin.H{
-        "status":  "OK",
-		"message":	"Success",
-        "data": data,
-    })
+}
+
+// LookupBooking godoc
+// @Summary Look up a booking by record locator
+// @Description Retrieve a booking with its record locator and a passenger last name, no login required
+// @Tags booking
+// @Produce json
+// @Param recordLocator query string true "6-character record locator"
+// @Param lastName query string true "Last name of any passenger on the booking"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /booking/lookup [get]
+func (bc *BookingController) LookupBooking(c *gin.Context) {
+	var req validations.LookupBookingRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var booking models.Booking
+	err := bc.BookingCollection.FindOne(ctx, bson.M{"recordLocator": strings.ToUpper(req.RecordLocator)}).Decode(&booking)
+	if err != nil && err != mongo.ErrNoDocuments {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch booking"})
+		return
+	}
+
+	// locator salah & nama salah dapat response yang sama, biar gak bisa ditebak
+	matched := false
+	for _, p := range booking.Passengers {
+		if strings.EqualFold(strings.TrimSpace(p.LastName), strings.TrimSpace(req.LastName)) {
+			matched = true
+			break
+		}
+	}
+	if err == mongo.ErrNoDocuments || !matched {
+		c.JSON(http.StatusNotFound, gin.H{"error": "booking not found"})
+		return
+	}
+
+	flights, err := services.LoadBookingFlights(ctx, bc.FlightCollection, booking)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch flight"})
+		return
+	}
+
+	// tanpa login → jangan tampilkan nomor dokumen & data payment
+	passengers := make([]gin.H, 0, len(booking.Passengers))
+	for _, p := range booking.Passengers {
+		passengers = append(passengers, gin.H{
+			"firstName":  p.FirstName,
+			"lastName":   p.LastName,
+			"seatNumber": p.SeatNumber,
+		})
+	}
+	data := bookingDetailData(booking, flights)
+	data["passengers"] = passengers
+	delete(data, "paymentId")
+	delete(data, "statusHistory")
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "OK",
+		"message": "Success",
+		"data":    data,
+	})
+}
+
+// PayBooking godoc
+// @Summary Pay for a pending booking
+// @Description Charge the booking total through the payment provider, booking becomes confirmed once the payment is captured
+// @Tags booking
+// @Accept json
+// @Produce json
+// @Param id path string true "Booking ID"
+// @Param payment body validations.PayBookingRequest true "Payment request body"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 402 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /book/{id}/pay [post]
+func (bc *BookingController) PayBooking(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	bookingObjID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid booking id"})
+		return
+	}
+
+	var req validations.PayBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var booking models.Booking
+	err = bc.BookingCollection.FindOne(ctx, bson.M{"_id": bookingObjID, "userId": userID}).Decode(&booking)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "booking not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch booking"})
+		}
+		return
+	}
+
+	if !booking.Status.AwaitingPayment() {
+		c.JSON(http.StatusConflict, gin.H{"error": "booking is not awaiting payment"})
+		return
+	}
+	if booking.HoldExpiresAt != nil && !booking.HoldExpiresAt.After(time.Now()) {
+		c.JSON(http.StatusConflict, gin.H{"error": "hold has expired"})
+		return
+	}
+
+	payment, err := bc.PaymentService.Charge(ctx, booking, booking.TotalPrice, req.PaymentToken)
+	if err != nil {
+		c.JSON(http.StatusPaymentRequired, gin.H{"error": "payment failed: " + err.Error()})
+		return
+	}
+
+	// konfirmasi booking cuma kalau masih nunggu bayar & hold belum lewat
+	err = services.ConfirmPaidBooking(ctx, bc.BookingCollection, booking, payment.ID, userID.Hex())
+	if err != nil {
+		// booking keburu expired/cancel → balikin uangnya
+		if _, refundErr := bc.PaymentService.RefundPayment(ctx, payment, payment.Amount, "booking could not be confirmed"); refundErr != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "booking could not be confirmed and refund failed, please contact support"})
+			return
+		}
+		c.JSON(http.StatusConflict, gin.H{"error": "booking could not be confirmed, payment has been refunded"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "payment captured, booking confirmed",
+		"payment": payment,
+	})
 }
 
 // update booking to cancelled
@@ -360,6 +565,20 @@ func (bc *BookingController) CancelBooking(c *gin.Context) {
 		return
 	}
 
+	actor, err := utils.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	// user cuma boleh cancel booking miliknya sendiri, admin boleh semua
+	filter := bson.M{"_id": bookingObjID}
+	reason := "cancelled by admin"
+	if role, _ := c.Get("role"); role != "admin" {
+		filter["userId"] = actor
+		reason = "cancelled by user"
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -371,41 +590,42 @@ func (bc *BookingController) CancelBooking(c *gin.Context) {
 	}
 	defer session.EndSession(ctx)
 
+	var booking models.Booking
+	var quote services.RefundQuote
 	err = mongo.WithSession(ctx, session, func(sc mongo.SessionContext) error {
 		if err := session.StartTransaction(); err != nil {
 			return err
 		}
 
 		// fetch booking
-		var booking models.Booking
-		if err := bc.BookingCollection.FindOne(sc, bson.M{"_id": bookingObjID}).Decode(&booking); err != nil {
+		if err := bc.BookingCollection.FindOne(sc, filter).Decode(&booking); err != nil {
 			return err
 		}
 
-		if booking.Status != "confirmed" {
+		flights, err := services.LoadBookingFlights(sc, bc.FlightCollection, booking)
+		if err != nil {
+			return err
+		}
+		quote = bc.refundQuote(booking, flights)
+
+		// update booking status lewat state machine (filter status biar gak balapan sama hold sweeper)
+		err = services.TransitionBooking(sc, bc.BookingCollection, bookingObjID, booking.Status, services.Transition{
+			To:     models.BookingStatusCancelled,
+			Actor:  actor.Hex(),
+			Reason: reason,
+			Set:    bson.M{"refundAmount": quote.RefundAmount},
+			Unset:  []string{"holdExpiresAt"},
+		})
+		if errors.Is(err, services.ErrIllegalTransition) || errors.Is(err, services.ErrBookingChanged) {
 			return errors.New("booking is not active")
 		}
-
-		// update booking status
-		_, err = bc.BookingCollection.UpdateOne(
-			sc,
-			bson.M{"_id": bookingObjID},
-			bson.M{"$set": bson.M{"status": "cancelled", "updated_at": time.Now()}},
-		)
 		if err != nil {
 			return err
 		}
 
-		// release seats back to available
-		for _, seat := range booking.Seats {
-			_, err := bc.FlightCollection.UpdateOne(
-				sc,
-				bson.M{"_id": booking.FlightID, "seats.number": seat.Number},
-				bson.M{"$set": bson.M{"seats.$.is_available": true, "updated_at": time.Now()}},
-			)
-			if err != nil {
-				return err
-			}
+		// release seats back to available (semua segmen)
+		if err := services.ReleaseBookingSeats(sc, bc.FlightCollection, booking); err != nil {
+			return err
 		}
 
 		return session.CommitTransaction(sc)
@@ -413,10 +633,147 @@ func (bc *BookingController) CancelBooking(c *gin.Context) {
 
 	if err != nil {
 		session.AbortTransaction(ctx)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "booking not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "booking cancelled successfully"})
+	// kursi kebuka → tawarkan ke antrian waitlist terdepan
+	bc.Waitlist.OfferFreedSeatsForBooking(ctx, booking)
+
+	// booking yang sudah dibayar → refund sesuai policy lewat provider yang sama
+	if quote.RefundAmount > 0 {
+		refunded, err := bc.PaymentService.RefundBooking(ctx, booking.ID, quote.RefundAmount, "booking cancelled")
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":    "booking cancelled but refund failed: " + err.Error(),
+				"refunded": refunded,
+			})
+			return
+		}
+
+		err = services.TransitionBooking(ctx, bc.BookingCollection, booking.ID, models.BookingStatusCancelled, services.Transition{
+			To:     models.BookingStatusRefunded,
+			Actor:  services.ActorSystem,
+			Reason: fmt.Sprintf("refund of %.2f issued", refunded),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "refund issued but failed to update booking status"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "booking cancelled successfully", "refunded": refunded, "refund": quote})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "booking cancelled successfully", "refund": quote})
+}
+
+// PreviewCancellation godoc
+// @Summary Preview the refund for cancelling a booking
+// @Description Evaluate the cancellation policy against the flight departure time without cancelling
+// @Tags booking
+// @Produce json
+// @Param id path string true "Booking ID"
+// @Success 200 {object} services.RefundQuote
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /book/{id}/cancel/preview [get]
+func (bc *BookingController) PreviewCancellation(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	booking, ok := bc.findUserBooking(ctx, c, userID)
+	if !ok {
+		return
+	}
+	if !booking.Status.CanTransitionTo(models.BookingStatusCancelled) {
+		c.JSON(http.StatusConflict, gin.H{"error": "booking is not active"})
+		return
+	}
+
+	flights, err := services.LoadBookingFlights(ctx, bc.FlightCollection, booking)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch flight"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "OK",
+		"message": "Success",
+		"data":    bc.refundQuote(booking, flights),
+	})
+}
+
+// refundQuote → booking yang belum dibayar gak ada refund
+func (bc *BookingController) refundQuote(booking models.Booking, flights map[primitive.ObjectID]models.Flight) services.RefundQuote {
+	if booking.PaymentID == nil {
+		return services.RefundQuote{}
+	}
+	return bc.CancellationPolicies.Quote(booking, flights, time.Now())
 }
 
+// UpdateBookingStatus → admin pindahin status booking (ticketed, checked-in, flown, ...) lewat state machine
+func (bc *BookingController) UpdateBookingStatus(c *gin.Context) {
+	//check if admin
+	role, _ := c.Get("role")
+	if role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden, admin only"})
+		return
+	}
+
+	actor, err := utils.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	bookingObjID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid booking id"})
+		return
+	}
+
+	var req validations.UpdateBookingStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var booking models.Booking
+	if err := bc.BookingCollection.FindOne(ctx, bson.M{"_id": bookingObjID}).Decode(&booking); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "booking not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch booking"})
+		}
+		return
+	}
+
+	err = services.TransitionBooking(ctx, bc.BookingCollection, booking.ID, booking.Status, services.Transition{
+		To:     models.BookingStatus(req.Status),
+		Actor:  actor.Hex(),
+		Reason: req.Reason,
+	})
+	if errors.Is(err, services.ErrIllegalTransition) || errors.Is(err, services.ErrBookingChanged) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update booking status"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "booking status updated", "from": booking.Status, "to": req.Status})
+}
@@ -2,9 +2,10 @@ package controllers
 
 import (
 	"context"
-	"fmt"
-	"math"
+	"errors"
+	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -13,17 +14,34 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
+	"airplane_booking_go/config"
+	"airplane_booking_go/events"
 	"airplane_booking_go/models"
+	"airplane_booking_go/services"
 	"airplane_booking_go/utils"
 	"airplane_booking_go/validations"
 )
 
 type FlightController struct {
-	FlightCollection *mongo.Collection
+	FlightCollection   *mongo.Collection
+	AircraftCollection *mongo.Collection
+	AirportCollection  *mongo.Collection
+	AirlineCollection  *mongo.Collection
+	BookingCollection  *mongo.Collection
+	Waitlist           *services.Waitlist
+	Reaccommodator     *services.Reaccommodator
 }
 
-func NewFlightController(flightCollection *mongo.Collection) *FlightController {
-	return &FlightController{FlightCollection: flightCollection}
+func NewFlightController(flightCollection, aircraftCollection, airportCollection, airlineCollection, bookingCollection *mongo.Collection, waitlist *services.Waitlist, reaccommodator *services.Reaccommodator) *FlightController {
+	return &FlightController{
+		FlightCollection:   flightCollection,
+		AircraftCollection: aircraftCollection,
+		AirportCollection:  airportCollection,
+		AirlineCollection:  airlineCollection,
+		BookingCollection:  bookingCollection,
+		Waitlist:           waitlist,
+		Reaccommodator:     reaccommodator,
+	}
 }
 
 // // ========== REQUEST STRUCT ==========
@@ -49,67 +67,99 @@ func (fc *FlightController) CreateFlight(c *gin.Context) {
 		return
 	}
 
-	// generate seats otomatis
-	var seats []models.Seat
-	minPrice := math.MaxFloat64
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	// business seats
-	for i := 1; i <= req.SeatConfig.Business.Count; i++ {
-		seat := models.Seat{
-			Number:      fmt.Sprintf("B%d", i),
-			Class:       "business",
-			IsAvailable: true,
-			Price:       req.SeatConfig.Business.Price,
-		}
-		seats = append(seats, seat)
-		if seat.Price < minPrice {
-			minPrice = seat.Price
-		}
+	// maskapai + bandara dari data referensi, bukan dari request
+	airline, flightNumber, err := services.ResolveCarrier(ctx, fc.AirlineCollection, req.Airline, req.FlightNumber)
+	if err != nil {
+		writeAirlineError(c, err)
+		return
+	}
+	departure, arrival, err := services.ResolveRoute(ctx, fc.AirportCollection, req.Departure, req.Arrival)
+	if err != nil {
+		writeAirportError(c, err)
+		return
+	}
+	departureTime, arrivalTime, duration, err := services.FlightTimes(departure, arrival, req.DepartureTime, req.ArrivalTime)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	// economy seats
-	for i := 1; i <= req.SeatConfig.Economy.Count; i++ {
-		seat := models.Seat{
-			Number:      fmt.Sprintf("E%d", i),
-			Class:       "economy",
-			IsAvailable: true,
-			Price:       req.SeatConfig.Economy.Price,
+	// generate seats otomatis dari denah kabin (nomor kursi "12A", "12B", ...)
+	layout := req.Layout
+	var limits map[string]int
+	if req.AircraftType != "" {
+		// template kabin dari aircraft registry, harga dari fares
+		aircraft, aircraftLayout, err := services.AircraftLayout(ctx, fc.AircraftCollection, req.AircraftType, req.Fares)
+		if err != nil {
+			writeAircraftError(c, err)
+			return
 		}
-		seats = append(seats, seat)
-		if seat.Price < minPrice {
-			minPrice = seat.Price
+		req.AircraftType = aircraft.TypeCode
+		layout = &aircraftLayout
+	} else if layout == nil {
+		if req.SeatConfig.Business.Count+req.SeatConfig.Economy.Count == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "either aircraftType, layout or seatConfig is required"})
+			return
 		}
+		defaultLayout, seatLimits := services.DefaultCabinLayout(
+			req.SeatConfig.Business.Count, req.SeatConfig.Business.Price,
+			req.SeatConfig.Economy.Count, req.SeatConfig.Economy.Price,
+		)
+		layout, limits = &defaultLayout, seatLimits
 	}
 
-	newFlight := models.Flight{
-		ID:            primitive.NewObjectID(),
-		Airline:       req.Airline,
-		FlightNumber:  req.FlightNumber,
-		Departure:     req.Departure,
-		Arrival:       req.Arrival,
-		DepartureTime: req.DepartureTime,
-		ArrivalTime:   req.ArrivalTime,
-		Duration:      req.Duration,
-		MinPrice:      minPrice, // harga termurah
-		Seats:         seats,
-		CreatedAt:     time.Now(),
-		UpdatedAt:     time.Now(),
+	seats, err := services.GenerateSeats(*layout)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if limits != nil {
+		seats = services.LimitSeats(seats, limits)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	newFlight := models.Flight{
+		ID:             primitive.NewObjectID(),
+		Airline:        airline.Name,
+		CarrierCode:    airline.IATA,
+		AirlineLogoURL: airline.LogoURL,
+		FlightNumber:   flightNumber,
+		AircraftType:   req.AircraftType,
+		Departure:      departure,
+		Arrival:        arrival,
+		DepartureTime:  departureTime,
+		ArrivalTime:    arrivalTime,
+		Duration:       duration,
+		MinPrice:       services.MinSeatPrice(seats), // harga termurah
+		Status:         models.FlightStatusScheduled,
+		Seats:          seats,
+		CabinLayout:    layout,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
 
-	_, err := fc.FlightCollection.InsertOne(ctx, newFlight)
+	err = services.InTransaction(ctx, fc.FlightCollection.Database().Client(), func(ctx context.Context) error {
+		if _, err := fc.FlightCollection.InsertOne(ctx, newFlight); err != nil {
+			return err
+		}
+		return services.RecordEvent(ctx, fc.FlightCollection.Database(), events.FlightCreated, events.AggregateFlight, newFlight.ID, map[string]interface{}{
+			"source": "manual",
+		})
+	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to insert data"})
 		return
 	}
 
+	utils.SetAuditTarget(c, newFlight.ID.Hex())
+
 	c.JSON(http.StatusCreated, gin.H{
 		"code":    "200",
 		"status":  "OK",
 		"message": "flight created",
-		"flight":  newFlight,
+		"flight":  newFlight.WithLocalTimes(),
 	})
 }
 
@@ -127,12 +177,24 @@ func (fc *FlightController) GetAllFlights(c *gin.Context) {
 	if airline := c.Query("airline"); airline != "" {
 		filter["airline"] = bson.M{"$regex": airline, "$options": "i"}
 	}
+	if carrier := c.Query("carrier"); carrier != "" {
+		// IATA maskapai, exact (beda dengan airline yang cocokkan nama)
+		filter["carrierCode"] = strings.ToUpper(carrier)
+	}
 	if depCity := c.Query("departureCity"); depCity != "" {
 		filter["departure.city"] = bson.M{"$regex": depCity, "$options": "i"}
 	}
 	if arrCity := c.Query("arrivalCity"); arrCity != "" {
 		filter["arrival.city"] = bson.M{"$regex": arrCity, "$options": "i"}
 	}
+	if status := c.Query("status"); status != "" {
+		if status == string(models.FlightStatusScheduled) {
+			// flight lama belum punya field status
+			filter["status"] = bson.M{"$in": []interface{}{status, nil}}
+		} else {
+			filter["status"] = status
+		}
+	}
 	if depDate := c.Query("departureDate"); depDate != "" {
 		// filter by departure date only (ignore time)
 		t, err := time.Parse("2006-01-02", depDate)
@@ -190,23 +252,30 @@ func (fc *FlightController) GetAllFlights(c *gin.Context) {
 		totalSeats := len(f.Seats)
 		availableSeats := 0
 		for _, s := range f.Seats {
-			if s.IsAvailable {
+			if s.IsBookable() {
 				availableSeats++
 			}
 		}
 
 		response = append(response, gin.H{
-			"id":             f.ID.Hex(),
-			"airline":        f.Airline,
-			"flightNumber":   f.FlightNumber,
-			"departure":      f.Departure,
-			"arrival":        f.Arrival,
-			"departureTime":  f.DepartureTime,
-			"arrivalTime":    f.ArrivalTime,
-			"duration":       f.Duration,
-			"minPrice":       f.MinPrice,
-			"totalSeats":     totalSeats,
-			"availableSeats": availableSeats,
+			"id":                     f.ID.Hex(),
+			"airline":                f.Airline,
+			"carrierCode":            f.CarrierCode,
+			"airlineLogoUrl":         f.AirlineLogoURL,
+			"flightNumber":           f.FlightNumber,
+			"departure":              f.Departure,
+			"arrival":                f.Arrival,
+			"departureTime":          f.DepartureTime,
+			"arrivalTime":            f.ArrivalTime,
+			"departureLocalTime":     f.Departure.LocalTime(f.DepartureTime),
+			"arrivalLocalTime":       f.Arrival.LocalTime(f.ArrivalTime),
+			"duration":               f.Duration,
+			"flightStatus":           f.CurrentStatus(),
+			"estimatedDepartureTime": f.EstimatedDepartureTime,
+			"estimatedArrivalTime":   f.EstimatedArrivalTime,
+			"minPrice":               f.MinPrice,
+			"totalSeats":             totalSeats,
+			"availableSeats":         availableSeats,
 		})
 	}
 
@@ -247,27 +316,39 @@ func (fc *FlightController) GetFlightDetail(c *gin.Context) {
 	totalSeats := len(flight.Seats)
 	availableSeats := 0
 	for _, s := range flight.Seats {
-		if s.IsAvailable {
+		if s.IsBookable() {
 			availableSeats++
 		}
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"code":           200,
-		"status":         "OK",
-		"message":        "success get flight detail",
-		"id":             flight.ID.Hex(),
-		"airline":        flight.Airline,
-		"flightNumber":   flight.FlightNumber,
-		"departure":      flight.Departure,
-		"arrival":        flight.Arrival,
-		"departureTime":  flight.DepartureTime,
-		"arrivalTime":    flight.ArrivalTime,
-		"duration":       flight.Duration,
-		"minPrice":       flight.MinPrice,
-		"totalSeats":     totalSeats,
-		"availableSeats": availableSeats,
-		"seats":          flight.Seats, // full seat list
+		"code":                   200,
+		"status":                 "OK",
+		"message":                "success get flight detail",
+		"id":                     flight.ID.Hex(),
+		"airline":                flight.Airline,
+		"carrierCode":            flight.CarrierCode,
+		"airlineLogoUrl":         flight.AirlineLogoURL,
+		"flightNumber":           flight.FlightNumber,
+		"aircraftType":           flight.AircraftType,
+		"departure":              flight.Departure,
+		"arrival":                flight.Arrival,
+		"departureTime":          flight.DepartureTime,
+		"arrivalTime":            flight.ArrivalTime,
+		"departureLocalTime":     flight.Departure.LocalTime(flight.DepartureTime),
+		"arrivalLocalTime":       flight.Arrival.LocalTime(flight.ArrivalTime),
+		"duration":               flight.Duration,
+		"flightStatus":           flight.CurrentStatus(),
+		"statusHistory":          flight.StatusHistory,
+		"estimatedDepartureTime": flight.EstimatedDepartureTime,
+		"estimatedArrivalTime":   flight.EstimatedArrivalTime,
+		"actualDepartureTime":    flight.ActualDepartureTime,
+		"actualArrivalTime":      flight.ActualArrivalTime,
+		"minPrice":               flight.MinPrice,
+		"totalSeats":             totalSeats,
+		"availableSeats":         availableSeats,
+		"seats":                  flight.Seats, // full seat list + atribut (window/aisle, legroom, exit row)
+		"cabinLayout":            flight.CabinLayout,
 	})
 }
 
@@ -288,6 +369,25 @@ func (fc *FlightController) UpdateFlight(c *gin.Context) {
 		return
 	}
 
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	airline, flightNumber, err := services.ResolveCarrier(ctx, fc.AirlineCollection, req.Airline, req.FlightNumber)
+	if err != nil {
+		writeAirlineError(c, err)
+		return
+	}
+	departure, arrival, err := services.ResolveRoute(ctx, fc.AirportCollection, req.Departure, req.Arrival)
+	if err != nil {
+		writeAirportError(c, err)
+		return
+	}
+	departureTime, arrivalTime, duration, err := services.FlightTimes(departure, arrival, req.DepartureTime, req.ArrivalTime)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	// hitung harga seat termurah
 	if len(req.Seats) == 0 {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "seats cannot be empty"})
@@ -301,36 +401,298 @@ func (fc *FlightController) UpdateFlight(c *gin.Context) {
 	}
 
 	update := bson.M{
-		"airline":        req.Airline,
-		"flight_number":  req.FlightNumber,
-		"departure":      req.Departure,
-		"arrival":        req.Arrival,
-		"departure_time": req.DepartureTime,
-		"arrival_time":   req.ArrivalTime,
-		"duration":       req.Duration,
-		"price":          minPrice,
+		"airline":        airline.Name,
+		"carrierCode":    airline.IATA,
+		"airlineLogoUrl": airline.LogoURL,
+		"flightNumber":   flightNumber,
+		"departure":      departure,
+		"arrival":        arrival,
+		"departureTime":  departureTime,
+		"arrivalTime":    arrivalTime,
+		"duration":       duration,
+		"minPrice":       minPrice,
 		"seats":          req.Seats,
-		"updated_at":     time.Now(),
+		"updatedAt":      time.Now(),
+	}
+
+	err = services.InTransaction(ctx, fc.FlightCollection.Database().Client(), func(ctx context.Context) error {
+		var previous models.Flight
+		err := fc.FlightCollection.FindOneAndUpdate(ctx,
+			bson.M{"_id": objID},
+			bson.M{"$set": update},
+		).Decode(&previous)
+		if err != nil {
+			return err
+		}
+
+		// jam / bandara berubah → penumpang dikabari (lihat NotificationService)
+		var data map[string]interface{}
+		if !previous.DepartureTime.Equal(departureTime) || !previous.ArrivalTime.Equal(arrivalTime) ||
+			previous.Departure.Code != departure.Code || previous.Arrival.Code != arrival.Code {
+			data = map[string]interface{}{
+				"changeType":                "schedule_change",
+				"previousDeparture":         previous.Departure.Code,
+				"previousArrival":           previous.Arrival.Code,
+				"previousDepartureTime":     previous.DepartureTime.UTC().Format(time.RFC3339),
+				"previousArrivalTime":       previous.ArrivalTime.UTC().Format(time.RFC3339),
+				"previousDepartureTimeZone": previous.Departure.TimeZone,
+				"previousArrivalTimeZone":   previous.Arrival.TimeZone,
+			}
+		}
+		return services.RecordEvent(ctx, fc.FlightCollection.Database(), events.FlightUpdated, events.AggregateFlight, objID, data)
+	})
+	if err == mongo.ErrNoDocuments {
+		c.JSON(http.StatusNotFound, gin.H{"error": "flight not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update flight"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "flight updated successfully"})
+}
+
+// ChangeAircraft → ganti pesawat (equipment change) di flight yang sudah ada.
+// Penumpang dipindah ke kursi setara di seat map baru, yang gak kebagian kursi dilaporkan di "unseated".
+func (fc *FlightController) ChangeAircraft(c *gin.Context) {
+	flightObjID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid flight id"})
+		return
+	}
+
+	actor, err := utils.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req validations.ChangeAircraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var flight models.Flight
+	if err := fc.FlightCollection.FindOne(ctx, bson.M{"_id": flightObjID}).Decode(&flight); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "flight not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch flight"})
+		}
+		return
+	}
+	if !flight.DepartureTime.After(time.Now()) || flight.CurrentStatus().IsFinal() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "flight already departed"})
+		return
+	}
+
+	// fare gak diisi → pakai harga per class yang sekarang
+	fares := req.Fares
+	if len(fares) == 0 {
+		fares = services.ClassFares(flight.Seats)
+	}
+	aircraft, layout, err := services.AircraftLayout(ctx, fc.AircraftCollection, req.AircraftType, fares)
+	if err != nil {
+		writeAircraftError(c, err)
+		return
+	}
+	seats, err := services.GenerateSeats(layout)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid aircraft layout"})
+		return
+	}
+
+	bookings, err := services.FindFlightBookings(ctx, fc.BookingCollection, flight.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch bookings"})
+		return
+	}
+
+	plan := services.PlanEquipmentChange(flight.ID, seats, bookings)
+	err = services.ApplyEquipmentChange(ctx, fc.FlightCollection, fc.BookingCollection, fc.Waitlist, flight, aircraft.TypeCode, layout, plan, actor.Hex())
+	if err != nil {
+		if errors.Is(err, services.ErrFlightChanged) || errors.Is(err, services.ErrBookingChanged) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error() + ", please retry"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to change aircraft"})
+		return
+	}
+
+	// seat map baru mungkin punya kursi lebih → tawarkan ke waitlist
+	if fc.Waitlist != nil {
+		if _, err := fc.Waitlist.OfferFreedSeats(ctx, flight.ID); err != nil {
+			log.Printf("waitlist: offer after equipment change %s: %v\n", flight.ID.Hex(), err)
+		}
+	}
+
+	if plan.Unseated == nil {
+		plan.Unseated = []services.UnseatedPassenger{}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":         200,
+		"status":       "OK",
+		"message":      "aircraft changed",
+		"aircraftType": aircraft.TypeCode,
+		"totalSeats":   len(seats),
+		"bookings":     plan.Bookings,
+		"unseated":     plan.Unseated,
+	})
+}
+
+// UpdateFlightStatus → update status operasional (boarding, delayed, departed, ...) + jam estimasi/aktual (admin).
+// Pembatalan lewat CancelFlight supaya booking-nya ikut diproses.
+func (fc *FlightController) UpdateFlightStatus(c *gin.Context) {
+	flightObjID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid flight id"})
+		return
+	}
+
+	actor, err := utils.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req validations.UpdateFlightStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	result, err := fc.FlightCollection.UpdateOne(ctx,
-		bson.M{"_id": objID},
-		bson.M{"$set": update},
-	)
+	var flight models.Flight
+	if err := fc.FlightCollection.FindOne(ctx, bson.M{"_id": flightObjID}).Decode(&flight); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "flight not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch flight"})
+		}
+		return
+	}
+
+	err = services.UpdateFlightStatus(ctx, fc.FlightCollection, flight, services.FlightStatusUpdate{
+		Status:                 models.FlightStatus(req.Status),
+		Reason:                 req.Reason,
+		EstimatedDepartureTime: req.EstimatedDepartureTime,
+		EstimatedArrivalTime:   req.EstimatedArrivalTime,
+		ActualDepartureTime:    req.ActualDepartureTime,
+		ActualArrivalTime:      req.ActualArrivalTime,
+	}, actor.Hex())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update flight"})
+		switch {
+		case errors.Is(err, services.ErrIllegalFlightTransition):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrFlightChanged):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error() + ", please retry"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update flight status"})
+		}
 		return
 	}
 
-	if result.MatchedCount == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "flight not found"})
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"status":  "OK",
+		"message": "flight status updated",
+		"from":    flight.CurrentStatus(),
+		"to":      req.Status,
+	})
+}
+
+// CancelFlight → batalkan flight (admin) lalu proses semua booking-nya sesuai policy re-accommodation.
+// Kalau flight sudah cancelled, booking yang tersisa diproses ulang.
+func (fc *FlightController) CancelFlight(c *gin.Context) {
+	flightObjID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid flight id"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "flight updated successfully"})
+	actor, err := utils.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req validations.CancelFlightRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Policy == "" {
+		req.Policy = services.ReaccommodationRebook
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	var flight models.Flight
+	if err := fc.FlightCollection.FindOne(ctx, bson.M{"_id": flightObjID}).Decode(&flight); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "flight not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch flight"})
+		}
+		return
+	}
+
+	// status dulu biar gak ada booking baru masuk selama diproses
+	if flight.CurrentStatus() != models.FlightStatusCancelled {
+		err = services.UpdateFlightStatus(ctx, fc.FlightCollection, flight, services.FlightStatusUpdate{
+			Status: models.FlightStatusCancelled,
+			Reason: req.Reason,
+		}, actor.Hex())
+		if err != nil {
+			switch {
+			case errors.Is(err, services.ErrIllegalFlightTransition):
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			case errors.Is(err, services.ErrFlightChanged):
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error() + ", please retry"})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to cancel flight"})
+			}
+			return
+		}
+	}
+
+	window := time.Duration(req.WindowHours) * time.Hour
+	outcomes, err := fc.Reaccommodator.Reaccommodate(ctx, flight, req.Policy, window, actor.Hex())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "flight cancelled but failed to process bookings: " + err.Error()})
+		return
+	}
+
+	summary := map[string]int{}
+	for _, outcome := range outcomes {
+		summary[outcome.Outcome]++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":     200,
+		"status":   "OK",
+		"message":  "flight cancelled",
+		"policy":   req.Policy,
+		"summary":  summary,
+		"bookings": outcomes,
+	})
+}
+
+// writeAircraftError → aircraft gak dikenal / fare kurang = salah request, selain itu error server
+func writeAircraftError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrUnknownAircraft) || errors.Is(err, services.ErrMissingFare) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch aircraft"})
 }
 
 func (fc *FlightController) SearchFlights(c *gin.Context) {
@@ -347,6 +709,9 @@ func (fc *FlightController) SearchFlights(c *gin.Context) {
 	if req.Airline != "" {
 		filter["airline"] = req.Airline
 	}
+	if req.Carrier != "" {
+		filter["carrierCode"] = strings.ToUpper(req.Carrier)
+	}
 	if req.MinPrice > 0 || req.MaxPrice > 0 {
 		priceFilter := bson.M{}
 		if req.MinPrice > 0 {
@@ -388,6 +753,9 @@ func (fc *FlightController) SearchFlights(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to decode flights"})
 		return
 	}
+	for i := range flights {
+		flights[i] = flights[i].WithLocalTimes()
+	}
 
 	totalPages := int((totalCount + int64(p.Limit) - 1) / int64(p.Limit)) // ceil
 
@@ -400,3 +768,65 @@ func (fc *FlightController) SearchFlights(c *gin.Context) {
 		"flights":    flights,
 	})
 }
+
+// SearchItineraries → cari itinerary asal → tujuan (direct + maksimal 2 transit), urut harga / durasi
+func (fc *FlightController) SearchItineraries(c *gin.Context) {
+	var req validations.SearchItineraryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	origin, destination, err := services.ResolveRoute(ctx, fc.AirportCollection, req.From, req.To)
+	if err != nil {
+		writeAirportError(c, err)
+		return
+	}
+
+	// tanggal dibaca di zona waktu bandara asal, UTC kalau belum diisi
+	loc := origin.Location()
+	if loc == nil {
+		loc = time.UTC
+	}
+	date, err := time.ParseInLocation("2006-01-02", req.Date, loc)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid date"})
+		return
+	}
+
+	maxTravel := config.MaxTravelTime()
+	if req.MaxTravelHours > 0 {
+		maxTravel = time.Duration(req.MaxTravelHours) * time.Hour
+	}
+
+	itineraries, err := services.SearchItineraries(ctx, fc.FlightCollection, fc.AirportCollection, services.ItinerarySearch{
+		From:           origin,
+		To:             destination,
+		Date:           date,
+		Passengers:     req.Passengers,
+		Class:          req.Class,
+		MaxConnections: req.MaxConnections,
+		MaxTravelTime:  maxTravel,
+		MinConnection:  config.MinConnectionTime(),
+		SortBy:         req.SortBy,
+		Limit:          req.Limit,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to search itineraries"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":        200,
+		"status":      "OK",
+		"message":     "success search itineraries",
+		"from":        origin,
+		"to":          destination,
+		"date":        req.Date,
+		"total":       len(itineraries),
+		"itineraries": itineraries,
+	})
+}
@@ -0,0 +1,150 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"airplane_booking_go/config"
+	"airplane_booking_go/models"
+	"airplane_booking_go/services"
+	"airplane_booking_go/utils"
+	"airplane_booking_go/validations"
+)
+
+// CreateItineraryBooking godoc
+// @Summary Book several flights in one booking
+// @Description Reserve seats on every segment (round-trip or connecting legs) in a single transaction, either all seats are taken or none
+// @Tags booking
+// @Accept json
+// @Produce json
+// @Param booking body validations.CreateItineraryBookingRequest true "Itinerary booking request body"
+// @Success 201 {object} models.Booking
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /booking/itinerary [post]
+func (bc *BookingController) CreateItineraryBooking(c *gin.Context) {
+	var req validations.CreateItineraryBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	flightIDs := make([]primitive.ObjectID, 0, len(req.Segments))
+	for i, segment := range req.Segments {
+		flightID, err := primitive.ObjectIDFromHex(segment.FlightID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("segment %d: invalid flightId", i+1)})
+			return
+		}
+		if len(segment.SeatNumbers) != len(req.Passengers) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("segment %d: need exactly one seat per passenger", i+1)})
+			return
+		}
+		flightIDs = append(flightIDs, flightID)
+	}
+
+	// passenger di-map ke kursi segmen pertama, segmen lain ikut urutan yang sama
+	passengers, err := validations.ValidatePassengers(req.Passengers, req.Segments[0].SeatNumbers)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := context.Background()
+	session, err := bc.FlightCollection.Database().Client().StartSession()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to start session"})
+		return
+	}
+	defer session.EndSession(ctx)
+
+	var booking models.Booking
+
+	// semua leg dalam satu transaksi, satu gagal → semua batal
+	callback := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		flights := make([]models.Flight, 0, len(flightIDs))
+		for i, flightID := range flightIDs {
+			var flight models.Flight
+			if err := bc.FlightCollection.FindOne(sessCtx, bson.M{"_id": flightID}).Decode(&flight); err != nil {
+				return nil, fmt.Errorf("segment %d: flight not found", i+1)
+			}
+			flights = append(flights, flight)
+		}
+		hubs := make([]string, 0, len(flights))
+		for _, flight := range flights[1:] {
+			hubs = append(hubs, flight.Departure.Code)
+		}
+		minConnection, err := services.MinConnectionTimes(sessCtx, bc.AirportCollection, hubs, config.MinConnectionTime())
+		if err != nil {
+			return nil, err
+		}
+		if err := services.ValidateItinerary(flights, minConnection); err != nil {
+			return nil, err
+		}
+
+		segments := make([]models.BookingSegment, 0, len(flights))
+		totalPrice := 0.0
+		for i, flight := range flights {
+			seats, subTotal, err := services.SelectSeats(flight, req.Segments[i].SeatNumbers)
+			if err != nil {
+				return nil, fmt.Errorf("segment %d: %v", i+1, err)
+			}
+			if err := services.ClaimSeats(sessCtx, bc.FlightCollection, flight.ID, req.Segments[i].SeatNumbers); err != nil {
+				return nil, fmt.Errorf("segment %d: %v", i+1, err)
+			}
+			segments = append(segments, models.BookingSegment{FlightID: flight.ID, Seats: seats, SubTotal: subTotal})
+			totalPrice += subTotal
+		}
+
+		recordLocator, err := services.NewRecordLocator(sessCtx, bc.BookingCollection)
+		if err != nil {
+			return nil, err
+		}
+
+		booking = services.NewBooking(userID, recordLocator, req.Hold, config.HoldTTL())
+		booking.FlightID = segments[0].FlightID
+		booking.Seats = segments[0].Seats
+		booking.Segments = segments
+		booking.Passengers = passengers
+		booking.TotalPrice = totalPrice
+
+		if _, err := bc.BookingCollection.InsertOne(sessCtx, booking); err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to insert booking: %v", err)
+		}
+		return nil, services.RecordBookingCreated(sessCtx, bc.BookingCollection, booking, "itinerary")
+	}
+
+	// ulang kalau record locator bentrok di unique index
+	for attempt := 0; attempt < 3; attempt++ {
+		_, err = session.WithTransaction(ctx, callback)
+		if !mongo.IsDuplicateKeyError(err) {
+			break
+		}
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	utils.SetAuditTarget(c, booking.ID.Hex())
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "booking created",
+		"booking": booking,
+	})
+}
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"airplane_booking_go/models"
+	"airplane_booking_go/services"
+)
+
+type PaymentController struct {
+	PaymentService    *services.PaymentService
+	BookingCollection *mongo.Collection
+}
+
+func NewPaymentController(paymentService *services.PaymentService, bookingColl *mongo.Collection) *PaymentController {
+	return &PaymentController{
+		PaymentService:    paymentService,
+		BookingCollection: bookingColl,
+	}
+}
+
+// HandleWebhook → terima notifikasi async dari payment provider
+func (pc *PaymentController) HandleWebhook(c *gin.Context) {
+	payload, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read payload"})
+		return
+	}
+
+	event, err := pc.PaymentService.Provider.VerifyWebhook(payload, c.GetHeader("X-Payment-Signature"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var payment models.Payment
+	err = pc.PaymentService.PaymentCollection.FindOne(ctx, bson.M{"authorizationId": event.AuthorizationID}).Decode(&payment)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "payment not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch payment"})
+		}
+		return
+	}
+
+	update := bson.M{"updatedAt": time.Now()}
+	switch event.Type {
+	case "payment.captured":
+		update["status"] = "captured"
+		if event.CaptureID != "" {
+			update["captureId"] = event.CaptureID
+		}
+	case "payment.failed":
+		update["status"] = "failed"
+	case "payment.refunded":
+		// refund selalu dimulai dari sini lewat RefundPayment (sudah tercatat + booking sudah ditransisi),
+		// refund yang dimulai dari sisi provider gak dipercaya → di-ack tapi diabaikan
+		c.JSON(http.StatusOK, gin.H{"message": "event ignored"})
+		return
+	default:
+		// event lain diabaikan tapi tetap di-ack biar provider gak retry
+		c.JSON(http.StatusOK, gin.H{"message": "event ignored"})
+		return
+	}
+
+	if _, err := pc.PaymentService.PaymentCollection.UpdateOne(ctx, bson.M{"_id": payment.ID}, bson.M{"$set": update}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update payment"})
+		return
+	}
+
+	// capture yang datang async → konfirmasi booking kalau masih nunggu bayar
+	if event.Type == "payment.captured" {
+		var booking models.Booking
+		if err := pc.BookingCollection.FindOne(ctx, bson.M{"_id": payment.BookingID}).Decode(&booking); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch booking"})
+			return
+		}
+		if booking.Status.AwaitingPayment() {
+			err := services.ConfirmPaidBooking(ctx, pc.BookingCollection, booking, payment.ID, pc.PaymentService.Provider.Name())
+			if err != nil && !errors.Is(err, services.ErrBookingChanged) {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to confirm booking"})
+				return
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "webhook processed"})
+}
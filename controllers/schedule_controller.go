@@ -0,0 +1,316 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"airplane_booking_go/models"
+	"airplane_booking_go/services"
+	"airplane_booking_go/utils"
+	"airplane_booking_go/validations"
+)
+
+type ScheduleController struct {
+	ScheduleCollection *mongo.Collection
+	AircraftCollection *mongo.Collection
+	AirportCollection  *mongo.Collection
+	AirlineCollection  *mongo.Collection
+	Generator          *services.ScheduleGenerator
+}
+
+func NewScheduleController(scheduleCollection, aircraftCollection, airportCollection, airlineCollection *mongo.Collection, generator *services.ScheduleGenerator) *ScheduleController {
+	return &ScheduleController{
+		ScheduleCollection: scheduleCollection,
+		AircraftCollection: aircraftCollection,
+		AirportCollection:  airportCollection,
+		AirlineCollection:  airlineCollection,
+		Generator:          generator,
+	}
+}
+
+// CreateSchedule → bikin jadwal rutin baru (admin), flight-nya dibuat oleh generator
+func (sc *ScheduleController) CreateSchedule(c *gin.Context) {
+	var req validations.ScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	schedule, ok := sc.scheduleFromRequest(ctx, c, req)
+	if !ok || !sc.validateSchedule(ctx, c, schedule) {
+		return
+	}
+	schedule.ID = primitive.NewObjectID()
+	schedule.CreatedAt = time.Now()
+	schedule.UpdatedAt = time.Now()
+
+	if _, err := sc.ScheduleCollection.InsertOne(ctx, schedule); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to insert schedule"})
+		return
+	}
+
+	utils.SetAuditTarget(c, schedule.ID.Hex())
+
+	c.JSON(http.StatusCreated, gin.H{
+		"code":     201,
+		"status":   "Created",
+		"message":  "schedule created",
+		"schedule": schedule,
+	})
+}
+
+// GetAllSchedules → list jadwal, bisa filter ?flightNumber= dan ?active=
+func (sc *ScheduleController) GetAllSchedules(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if flightNumber := c.Query("flightNumber"); flightNumber != "" {
+		filter["flightNumber"] = strings.ToUpper(flightNumber)
+	}
+	if active := c.Query("active"); active != "" {
+		filter["active"] = active == "true"
+	}
+
+	cursor, err := sc.ScheduleCollection.Find(ctx, filter, options.Find().SetSort(bson.M{"flightNumber": 1}))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch schedules"})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	schedules := []models.FlightSchedule{}
+	if err := cursor.All(ctx, &schedules); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to decode schedules"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":      200,
+		"status":    "OK",
+		"message":   "success get schedules",
+		"schedules": schedules,
+	})
+}
+
+func (sc *ScheduleController) GetSchedule(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	schedule, ok := sc.findSchedule(ctx, c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":     200,
+		"status":   "OK",
+		"message":  "success get schedule",
+		"schedule": schedule,
+	})
+}
+
+// UpdateSchedule → ubah jadwal, cuma berlaku untuk flight yang belum di-generate
+func (sc *ScheduleController) UpdateSchedule(c *gin.Context) {
+	scheduleID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid schedule id"})
+		return
+	}
+
+	var req validations.ScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	schedule, ok := sc.scheduleFromRequest(ctx, c, req)
+	if !ok || !sc.validateSchedule(ctx, c, schedule) {
+		return
+	}
+
+	result, err := sc.ScheduleCollection.UpdateOne(ctx,
+		bson.M{"_id": scheduleID},
+		bson.M{"$set": bson.M{
+			"airline":        schedule.Airline,
+			"carrierCode":    schedule.CarrierCode,
+			"airlineLogoUrl": schedule.AirlineLogoURL,
+			"flightNumber":   schedule.FlightNumber,
+			"departure":      schedule.Departure,
+			"arrival":        schedule.Arrival,
+			"departureTime":  schedule.DepartureTime,
+			"duration":       schedule.Duration,
+			"timeZone":       schedule.TimeZone,
+			"daysOfWeek":     schedule.DaysOfWeek,
+			"validFrom":      schedule.ValidFrom,
+			"validTo":        schedule.ValidTo,
+			"aircraftType":   schedule.AircraftType,
+			"fares":          schedule.Fares,
+			"active":         schedule.Active,
+			"updatedAt":      time.Now(),
+		}},
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update schedule"})
+		return
+	}
+	if result.MatchedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "schedule not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "schedule updated successfully"})
+}
+
+// DeleteSchedule → hapus jadwal, flight yang sudah di-generate tetap ada
+func (sc *ScheduleController) DeleteSchedule(c *gin.Context) {
+	scheduleID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid schedule id"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := sc.ScheduleCollection.DeleteOne(ctx, bson.M{"_id": scheduleID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete schedule"})
+		return
+	}
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "schedule not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "schedule deleted successfully"})
+}
+
+// GenerateFlights → jalankan generator untuk satu jadwal sekarang juga (default sejauh scheduleHorizon)
+func (sc *ScheduleController) GenerateFlights(c *gin.Context) {
+	var req validations.GenerateScheduleRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	schedule, ok := sc.findSchedule(ctx, c)
+	if !ok {
+		return
+	}
+	if !schedule.Active {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "schedule is not active"})
+		return
+	}
+
+	horizon := sc.Generator.Horizon
+	if req.Days > 0 {
+		horizon = time.Duration(req.Days) * 24 * time.Hour
+	}
+
+	result, err := sc.Generator.Generate(ctx, schedule, time.Now(), horizon)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate flights: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"status":  "OK",
+		"message": "flights generated",
+		"result":  result,
+	})
+}
+
+// scheduleFromRequest → jadwal dari request, maskapai + bandara diambil dari data referensi
+func (sc *ScheduleController) scheduleFromRequest(ctx context.Context, c *gin.Context, req validations.ScheduleRequest) (models.FlightSchedule, bool) {
+	airline, flightNumber, err := services.ResolveCarrier(ctx, sc.AirlineCollection, req.Airline, req.FlightNumber)
+	if err != nil {
+		writeAirlineError(c, err)
+		return models.FlightSchedule{}, false
+	}
+	departure, arrival, err := services.ResolveRoute(ctx, sc.AirportCollection, req.Departure, req.Arrival)
+	if err != nil {
+		writeAirportError(c, err)
+		return models.FlightSchedule{}, false
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+	timeZone := req.TimeZone
+	if timeZone == "" {
+		timeZone = departure.TimeZone
+	}
+	return models.FlightSchedule{
+		Airline:        airline.Name,
+		CarrierCode:    airline.IATA,
+		AirlineLogoURL: airline.LogoURL,
+		FlightNumber:   flightNumber,
+		Departure:      departure,
+		Arrival:        arrival,
+		DepartureTime:  req.DepartureTime,
+		Duration:       req.Duration,
+		TimeZone:       timeZone,
+		DaysOfWeek:     req.DaysOfWeek,
+		ValidFrom:      req.ValidFrom,
+		ValidTo:        req.ValidTo,
+		AircraftType:   strings.ToUpper(req.AircraftType),
+		Fares:          req.Fares,
+		Active:         active,
+	}, true
+}
+
+// validateSchedule → cek timezone/tanggal valid dan aircraft + fare lengkap
+func (sc *ScheduleController) validateSchedule(ctx context.Context, c *gin.Context, schedule models.FlightSchedule) bool {
+	if schedule.TimeZone == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "timeZone is required when departure airport has no timeZone"})
+		return false
+	}
+	if _, err := services.ScheduleDates(schedule, time.Now(), time.Now()); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+	if _, _, err := services.AircraftLayout(ctx, sc.AircraftCollection, schedule.AircraftType, schedule.Fares); err != nil {
+		writeAircraftError(c, err)
+		return false
+	}
+	return true
+}
+
+func (sc *ScheduleController) findSchedule(ctx context.Context, c *gin.Context) (models.FlightSchedule, bool) {
+	scheduleID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid schedule id"})
+		return models.FlightSchedule{}, false
+	}
+
+	var schedule models.FlightSchedule
+	if err := sc.ScheduleCollection.FindOne(ctx, bson.M{"_id": scheduleID}).Decode(&schedule); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "schedule not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch schedule"})
+		}
+		return models.FlightSchedule{}, false
+	}
+	return schedule, true
+}
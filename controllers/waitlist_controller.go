@@ -0,0 +1,331 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"airplane_booking_go/config"
+	"airplane_booking_go/models"
+	"airplane_booking_go/services"
+	"airplane_booking_go/utils"
+	"airplane_booking_go/validations"
+)
+
+type WaitlistController struct {
+	Waitlist          *services.Waitlist
+	BookingCollection *mongo.Collection
+}
+
+func NewWaitlistController(waitlist *services.Waitlist, bookingColl *mongo.Collection) *WaitlistController {
+	return &WaitlistController{
+		Waitlist:          waitlist,
+		BookingCollection: bookingColl,
+	}
+}
+
+// JoinWaitlist → masuk antrian flight + class yang kursinya sudah habis
+func (wc *WaitlistController) JoinWaitlist(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req validations.JoinWaitlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	flightObjID, err := primitive.ObjectIDFromHex(req.FlightID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid flightId"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var flight models.Flight
+	if err := wc.Waitlist.FlightCollection.FindOne(ctx, bson.M{"_id": flightObjID}).Decode(&flight); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "flight not found"})
+		return
+	}
+	if !flight.DepartureTime.After(time.Now()) {
+		c.JSON(http.StatusConflict, gin.H{"error": "flight has already departed"})
+		return
+	}
+	if err := services.EnsureBookable(flight); err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	// masih ada kursi → langsung booking aja, gak perlu antri
+	if len(services.AvailableSeats(flight, req.Class)) >= req.SeatCount {
+		c.JSON(http.StatusConflict, gin.H{"error": "seats are still available in this class, book them directly"})
+		return
+	}
+
+	count, err := wc.Waitlist.WaitlistCollection.CountDocuments(ctx, bson.M{
+		"flightId": flightObjID,
+		"class":    req.Class,
+		"userId":   userID,
+		"status":   bson.M{"$in": []string{"waiting", "offered"}},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check waitlist"})
+		return
+	}
+	if count > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "already on the waitlist for this flight and class"})
+		return
+	}
+
+	entry := models.WaitlistEntry{
+		ID:        primitive.NewObjectID(),
+		FlightID:  flightObjID,
+		Class:     req.Class,
+		UserID:    userID,
+		SeatCount: req.SeatCount,
+		Status:    "waiting",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if _, err := wc.Waitlist.WaitlistCollection.InsertOne(ctx, entry); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to join waitlist"})
+		return
+	}
+
+	position, _ := wc.Waitlist.WaitlistCollection.CountDocuments(ctx, bson.M{
+		"flightId":  flightObjID,
+		"class":     req.Class,
+		"status":    "waiting",
+		"createdAt": bson.M{"$lte": entry.CreatedAt},
+	})
+
+	utils.SetAuditTarget(c, entry.ID.Hex())
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "joined waitlist",
+		"entry":    entry,
+		"position": position,
+	})
+}
+
+// GetUserWaitlist → daftar antrian milik user
+func (wc *WaitlistController) GetUserWaitlist(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	pagination := utils.GetPagination(c)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"userId": userID}
+	total, err := wc.Waitlist.WaitlistCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count waitlist"})
+		return
+	}
+
+	cursor, err := wc.Waitlist.WaitlistCollection.Find(ctx, filter,
+		options.Find().
+			SetSkip(int64(pagination.Skip)).
+			SetLimit(int64(pagination.Limit)).
+			SetSort(bson.D{{Key: "createdAt", Value: -1}}),
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch waitlist"})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var entries []models.WaitlistEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to parse waitlist"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":   "OK",
+		"total":    total,
+		"page":     pagination.Page,
+		"limit":    pagination.Limit,
+		"waitlist": entries,
+	})
+}
+
+// LeaveWaitlist → keluar dari antrian (atau tolak tawaran kursi)
+func (wc *WaitlistController) LeaveWaitlist(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	entry, ok := wc.findUserEntry(ctx, c, userID)
+	if !ok {
+		return
+	}
+
+	left, err := wc.Waitlist.Leave(ctx, entry)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to leave waitlist"})
+		return
+	}
+	if !left {
+		c.JSON(http.StatusConflict, gin.H{"error": "waitlist entry is no longer active"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "left waitlist"})
+}
+
+// ClaimWaitlistOffer → ambil kursi yang ditawarkan, jadi booking pending yang tinggal dibayar
+func (wc *WaitlistController) ClaimWaitlistOffer(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req validations.ClaimWaitlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	entry, ok := wc.findUserEntry(ctx, c, userID)
+	if !ok {
+		return
+	}
+	if entry.Status != "offered" || entry.OfferExpiresAt == nil || !entry.OfferExpiresAt.After(time.Now()) {
+		c.JSON(http.StatusConflict, gin.H{"error": "no active seat offer for this waitlist entry"})
+		return
+	}
+
+	passengers, err := validations.ValidatePassengers(req.Passengers, entry.OfferedSeats)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := wc.BookingCollection.Database().Client().StartSession()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to start session"})
+		return
+	}
+	defer session.EndSession(ctx)
+
+	var booking models.Booking
+	callback := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		var flight models.Flight
+		if err := wc.Waitlist.FlightCollection.FindOne(sessCtx, bson.M{"_id": entry.FlightID}).Decode(&flight); err != nil {
+			return nil, errors.New("flight not found")
+		}
+		if err := services.EnsureBookable(flight); err != nil {
+			return nil, err
+		}
+
+		// kursi sudah dikunci waktu ditawarkan, tinggal dipindah ke booking
+		seats := make([]models.Seat, 0, len(entry.OfferedSeats))
+		totalPrice := 0.0
+		for _, number := range entry.OfferedSeats {
+			for _, seat := range flight.Seats {
+				if seat.Number == number {
+					seats = append(seats, seat)
+					totalPrice += seat.Price
+				}
+			}
+		}
+		if len(seats) != len(entry.OfferedSeats) {
+			return nil, errors.New("offered seats no longer exist on this flight")
+		}
+
+		recordLocator, err := services.NewRecordLocator(sessCtx, wc.BookingCollection)
+		if err != nil {
+			return nil, err
+		}
+
+		booking = services.NewBooking(userID, recordLocator, false, config.HoldTTL())
+		booking.FlightID = entry.FlightID
+		booking.Seats = seats
+		booking.Passengers = passengers
+		booking.TotalPrice = totalPrice
+
+		res, err := wc.Waitlist.WaitlistCollection.UpdateOne(sessCtx,
+			bson.M{"_id": entry.ID, "status": "offered", "offerExpiresAt": bson.M{"$gt": time.Now()}},
+			bson.M{"$set": bson.M{"status": "claimed", "bookingId": booking.ID, "updatedAt": time.Now()}},
+		)
+		if err != nil {
+			return nil, err
+		}
+		if res.ModifiedCount == 0 {
+			return nil, errors.New("seat offer has expired")
+		}
+
+		if _, err := wc.BookingCollection.InsertOne(sessCtx, booking); err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to insert booking: %v", err)
+		}
+		return nil, services.RecordBookingCreated(sessCtx, wc.BookingCollection, booking, "waitlist")
+	}
+
+	for attempt := 0; attempt < 3; attempt++ {
+		_, err = session.WithTransaction(ctx, callback)
+		if !mongo.IsDuplicateKeyError(err) {
+			break
+		}
+	}
+	if err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "seat offer claimed, please complete payment",
+		"booking": booking,
+	})
+}
+
+func (wc *WaitlistController) findUserEntry(ctx context.Context, c *gin.Context, userID primitive.ObjectID) (models.WaitlistEntry, bool) {
+	var entry models.WaitlistEntry
+
+	entryID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid waitlist id"})
+		return entry, false
+	}
+
+	err = wc.Waitlist.WaitlistCollection.FindOne(ctx, bson.M{"_id": entryID, "userId": userID}).Decode(&entry)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "waitlist entry not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch waitlist entry"})
+		}
+		return entry, false
+	}
+	return entry, true
+}
@@ -0,0 +1,324 @@
+package controllers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"airplane_booking_go/models"
+	"airplane_booking_go/services"
+	"airplane_booking_go/utils"
+	"airplane_booking_go/validations"
+)
+
+type WebhookController struct {
+	SubscriptionCollection *mongo.Collection
+	DeliveryCollection     *mongo.Collection
+	Dispatcher             *services.WebhookDispatcher
+}
+
+func NewWebhookController(dispatcher *services.WebhookDispatcher) *WebhookController {
+	return &WebhookController{
+		SubscriptionCollection: dispatcher.SubscriptionCollection,
+		DeliveryCollection:     dispatcher.DeliveryCollection,
+		Dispatcher:             dispatcher,
+	}
+}
+
+// CreateSubscription → daftarkan endpoint webhook (admin). Secret cuma dikembalikan sekali di sini.
+func (wc *WebhookController) CreateSubscription(c *gin.Context) {
+	var req validations.WebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !validWebhookEvents(c, req.Events) {
+		return
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate secret"})
+			return
+		}
+		secret = generated
+	}
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	subscription := models.WebhookSubscription{
+		ID:          primitive.NewObjectID(),
+		URL:         req.URL,
+		Events:      req.Events,
+		Secret:      secret,
+		Description: req.Description,
+		Active:      active,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := wc.SubscriptionCollection.InsertOne(ctx, subscription); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to insert webhook subscription"})
+		return
+	}
+
+	utils.SetAuditTarget(c, subscription.ID.Hex())
+
+	c.JSON(http.StatusCreated, gin.H{
+		"code":         201,
+		"status":       "Created",
+		"message":      "webhook subscription created",
+		"subscription": subscription,
+	})
+}
+
+// GetAllSubscriptions → list subscription (tanpa secret)
+func (wc *WebhookController) GetAllSubscriptions(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := wc.SubscriptionCollection.Find(ctx, bson.M{},
+		options.Find().SetSort(bson.M{"createdAt": 1}).SetProjection(bson.M{"secret": 0}))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch webhook subscriptions"})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	subscriptions := []models.WebhookSubscription{}
+	if err := cursor.All(ctx, &subscriptions); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to decode webhook subscriptions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":          200,
+		"status":        "OK",
+		"message":       "success get webhook subscriptions",
+		"subscriptions": subscriptions,
+	})
+}
+
+func (wc *WebhookController) GetSubscription(c *gin.Context) {
+	subscriptionID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid subscription id"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var subscription models.WebhookSubscription
+	err = wc.SubscriptionCollection.FindOne(ctx, bson.M{"_id": subscriptionID},
+		options.FindOne().SetProjection(bson.M{"secret": 0})).Decode(&subscription)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "webhook subscription not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch webhook subscription"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":         200,
+		"status":       "OK",
+		"message":      "success get webhook subscription",
+		"subscription": subscription,
+	})
+}
+
+// UpdateSubscription → ubah url / event / status aktif, secret cuma diganti kalau dikirim
+func (wc *WebhookController) UpdateSubscription(c *gin.Context) {
+	subscriptionID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid subscription id"})
+		return
+	}
+
+	var req validations.WebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !validWebhookEvents(c, req.Events) {
+		return
+	}
+
+	set := bson.M{
+		"url":         req.URL,
+		"events":      req.Events,
+		"description": req.Description,
+		"updatedAt":   time.Now(),
+	}
+	if req.Active != nil {
+		set["active"] = *req.Active
+	}
+	if req.Secret != "" {
+		set["secret"] = req.Secret
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := wc.SubscriptionCollection.UpdateOne(ctx, bson.M{"_id": subscriptionID}, bson.M{"$set": set})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update webhook subscription"})
+		return
+	}
+	if result.MatchedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "webhook subscription not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "webhook subscription updated successfully"})
+}
+
+// DeleteSubscription → hapus subscription, delivery yang belum terkirim ikut dibuang
+func (wc *WebhookController) DeleteSubscription(c *gin.Context) {
+	subscriptionID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid subscription id"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := wc.SubscriptionCollection.DeleteOne(ctx, bson.M{"_id": subscriptionID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete webhook subscription"})
+		return
+	}
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "webhook subscription not found"})
+		return
+	}
+	wc.DeliveryCollection.DeleteMany(ctx, bson.M{"subscriptionId": subscriptionID, "status": "pending"})
+
+	c.JSON(http.StatusOK, gin.H{"message": "webhook subscription deleted successfully"})
+}
+
+// GetDeliveries → log pengiriman, filter ?subscriptionId= ?event= ?status=pending|delivered|dead
+func (wc *WebhookController) GetDeliveries(c *gin.Context) {
+	filter := bson.M{}
+	if subscriptionID := c.Query("subscriptionId"); subscriptionID != "" {
+		objID, err := primitive.ObjectIDFromHex(subscriptionID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid subscriptionId"})
+			return
+		}
+		filter["subscriptionId"] = objID
+	}
+	if event := c.Query("event"); event != "" {
+		filter["event"] = event
+	}
+	if status := c.Query("status"); status != "" {
+		filter["status"] = status
+	}
+	wc.listDeliveries(c, filter, "success get webhook deliveries")
+}
+
+// GetDeadLetters → delivery yang gagal permanen, bisa dikirim ulang lewat /retry
+func (wc *WebhookController) GetDeadLetters(c *gin.Context) {
+	wc.listDeliveries(c, bson.M{"status": "dead"}, "success get dead webhook deliveries")
+}
+
+func (wc *WebhookController) listDeliveries(c *gin.Context, filter bson.M, message string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pagination := utils.GetPagination(c)
+
+	total, err := wc.DeliveryCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count documents"})
+		return
+	}
+
+	cursor, err := wc.DeliveryCollection.Find(ctx, filter,
+		options.Find().
+			SetSkip(int64(pagination.Skip)).
+			SetLimit(int64(pagination.Limit)).
+			SetSort(bson.D{{Key: "createdAt", Value: -1}}),
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch webhook deliveries"})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	deliveries := []models.WebhookDelivery{}
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to decode webhook deliveries"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":       200,
+		"status":     "OK",
+		"message":    message,
+		"page":       pagination.Page,
+		"limit":      pagination.Limit,
+		"total":      total,
+		"deliveries": deliveries,
+	})
+}
+
+// RetryDelivery → kirim ulang delivery dari dead letter
+func (wc *WebhookController) RetryDelivery(c *gin.Context) {
+	deliveryID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid delivery id"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := wc.Dispatcher.Redeliver(ctx, deliveryID); err != nil {
+		if errors.Is(err, services.ErrDeliveryNotRetryable) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retry webhook delivery"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "webhook delivery queued for retry"})
+}
+
+func validWebhookEvents(c *gin.Context, events []string) bool {
+	for _, event := range events {
+		if !services.IsWebhookEvent(event) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "unknown event " + event, "events": services.WebhookEvents})
+			return false
+		}
+	}
+	return true
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,63 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+type subscription struct {
+	name    string
+	handler Handler
+}
+
+// Bus → publisher in-process, event diteruskan ke semua handler yang subscribe tipenya (atau "*")
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]subscription
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: map[string][]subscription{}}
+}
+
+// Subscribe → daftarkan handler untuk tipe event, "*" = semua event
+func (b *Bus) Subscribe(eventType, name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], subscription{name: name, handler: handler})
+}
+
+// Publish → jalankan semua handler berurutan. Satu handler gagal gak menghentikan yang lain,
+// tapi error-nya dikembalikan biar relay retry (handler wajib idempotent per Event.ID).
+func (b *Bus) Publish(ctx context.Context, event Event) error {
+	b.mu.RLock()
+	subs := append(append([]subscription{}, b.handlers[event.Type]...), b.handlers["*"]...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, sub := range subs {
+		if err := sub.handler(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sub.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Fanout → kirim ke beberapa publisher sekaligus (ex: bus in-process + broker)
+func Fanout(publishers ...Publisher) Publisher {
+	return fanout(publishers)
+}
+
+type fanout []Publisher
+
+func (f fanout) Publish(ctx context.Context, event Event) error {
+	var errs []error
+	for _, publisher := range f {
+		if err := publisher.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,51 @@
+package events
+
+import (
+	"context"
+	"time"
+)
+
+// domain event yang ditulis ke outbox
+const (
+	BookingCreated   = "booking.created"
+	BookingConfirmed = "booking.confirmed"
+	BookingUpdated   = "booking.updated"
+	BookingCancelled = "booking.cancelled"
+	BookingRefunded  = "booking.refunded"
+	BookingExpired   = "booking.expired"
+
+	FlightCreated       = "flight.created"
+	FlightUpdated       = "flight.updated"
+	FlightStatusChanged = "flight.status_changed"
+	FlightCancelled     = "flight.cancelled"
+)
+
+// All → semua tipe event yang dikenal
+var All = []string{
+	BookingCreated, BookingConfirmed, BookingUpdated, BookingCancelled, BookingRefunded, BookingExpired,
+	FlightCreated, FlightUpdated, FlightStatusChanged, FlightCancelled,
+}
+
+// aggregate pemilik event
+const (
+	AggregateBooking = "booking"
+	AggregateFlight  = "flight"
+)
+
+// Event → satu domain event. ID stabil antar retry, consumer pakai ini buat dedup.
+type Event struct {
+	ID            string                 `json:"id"`
+	Type          string                 `json:"type"`
+	AggregateType string                 `json:"aggregateType"`
+	AggregateID   string                 `json:"aggregateId"`
+	Data          map[string]interface{} `json:"data,omitempty"`
+	OccurredAt    time.Time              `json:"occurredAt"`
+}
+
+// Handler → consumer event, error = relay akan kirim ulang event ini
+type Handler func(ctx context.Context, event Event) error
+
+// Publisher → tujuan relay outbox. Bus in-process, adapter broker (Kafka, NATS, ...) cukup implement ini.
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
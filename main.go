@@ -3,7 +3,12 @@ package main
 import (
 	"airplane_booking_go/config"
   	"airplane_booking_go/router"
+	"airplane_booking_go/payments"
+	"airplane_booking_go/notifications"
+	"airplane_booking_go/events"
+	"airplane_booking_go/services"
 	_ "airplane_booking_go/docs"
+	"context"
 	"log"
 	"os"
 
@@ -24,12 +29,120 @@ func main() {
 	connectionString := os.Getenv("connectionString")
 	db := os.Getenv("db")
 	client := config.ConnectDB(connectionString)
+	config.EnsureIndexes(client, db)
+
+	// payment provider (sementara pakai fake provider lokal)
+	// secret wajib diisi, kalau kosong webhook /payments/webhook bisa dipalsukan
+	paymentWebhookSecret := os.Getenv("paymentWebhookSecret")
+	if paymentWebhookSecret == "" {
+		log.Fatal("Error paymentWebhookSecret is not set")
+	}
+	paymentService := services.NewPaymentService(
+		payments.NewFakeProvider(paymentWebhookSecret),
+		config.GetCollection(client, db, "payments"),
+		config.Currency(),
+	)
+
+	// antrian kursi buat flight yang penuh
+	waitlist := services.NewWaitlist(
+		config.GetCollection(client, db, "waitlist"),
+		config.GetCollection(client, db, "flights"),
+		config.WaitlistClaimWindow(),
+	)
+
+	// generator flight dari jadwal rutin
+	scheduleGenerator := services.NewScheduleGenerator(
+		config.GetCollection(client, db, "schedules"),
+		config.GetCollection(client, db, "flights"),
+		config.GetCollection(client, db, "aircraft"),
+		config.ScheduleHorizon(),
+		config.ScheduleGenerateInterval(),
+	)
+
+	// notifikasi ke penumpang (email / file / log, lihat notificationChannels)
+	templates, err := notifications.LoadTemplates(config.NotificationTemplateDir(), config.NotificationLocale())
+	if err != nil {
+		log.Fatal("Error load notification templates:", err)
+	}
+	var notifiers []notifications.Notifier
+	for _, channel := range config.NotificationChannels() {
+		switch channel {
+		case "log":
+			notifiers = append(notifiers, notifications.NewLogNotifier())
+		case "file":
+			notifiers = append(notifiers, notifications.NewFileNotifier(config.NotificationFile()))
+		case "email":
+			notifiers = append(notifiers, notifications.NewSMTPNotifier(
+				os.Getenv("smtpHost"),
+				os.Getenv("smtpPort"),
+				os.Getenv("smtpUsername"),
+				os.Getenv("smtpPassword"),
+				os.Getenv("smtpFrom"),
+			))
+		default:
+			log.Fatalf("Error unknown notification channel %q", channel)
+		}
+	}
+	notificationService := services.NewNotificationService(
+		config.GetCollection(client, db, "notifications"),
+		config.GetCollection(client, db, "users"),
+		config.GetCollection(client, db, "booking"),
+		config.GetCollection(client, db, "flights"),
+		config.Currency(),
+		notifiers,
+		templates,
+		config.NotificationMaxAttempts(),
+		config.NotificationInterval(),
+	)
+
+	// webhook keluar ke sistem lain (CRM, accounting), subscription diatur admin
+	webhookDispatcher := services.NewWebhookDispatcher(
+		config.GetCollection(client, db, "webhook_subscriptions"),
+		config.GetCollection(client, db, "webhook_deliveries"),
+		config.GetCollection(client, db, "booking"),
+		config.GetCollection(client, db, "flights"),
+		config.WebhookTimeout(),
+		config.WebhookMaxAttempts(),
+		config.WebhookInterval(),
+	)
+
+	// domain event dari outbox → consumer in-process (notifikasi + webhook)
+	bus := events.NewBus()
+	bus.Subscribe("*", "notifications", notificationService.HandleEvent)
+	bus.Subscribe("*", "webhooks", webhookDispatcher.HandleEvent)
+	outboxRelay := services.NewOutboxRelay(
+		config.GetCollection(client, db, services.OutboxCollection),
+		bus,
+		config.OutboxMaxAttempts(),
+		config.OutboxInterval(),
+	)
 
 	//router setup
 	r := gin.Default()
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	router.UserRoutes(r, client, db)
-  	router.FlightRoutes(r, client, db)
-	router.BookRoutes(r, client, db)
+  	router.FlightRoutes(r, client, db, paymentService, waitlist)
+	router.AircraftRoutes(r, client, db)
+	router.AirportRoutes(r, client, db)
+	router.AirlineRoutes(r, client, db)
+	router.ScheduleRoutes(r, client, db, scheduleGenerator)
+	router.BookRoutes(r, client, db, paymentService, waitlist)
+	router.PaymentRoutes(r, client, db, paymentService)
+	router.WebhookRoutes(r, client, db, webhookDispatcher)
+	router.AuditRoutes(r, client, db)
+
+	// background job: lepas kursi dari hold / tawaran waitlist yang expired
+	services.NewHoldSweeper(
+		config.GetCollection(client, db, "booking"),
+		config.GetCollection(client, db, "flights"),
+		waitlist,
+		config.HoldSweepInterval(),
+	).Start(context.Background())
+
+	scheduleGenerator.Start(context.Background())
+	notificationService.Start(context.Background())
+	webhookDispatcher.Start(context.Background())
+	outboxRelay.Start(context.Background())
+
   	r.Run(":8080")
 }
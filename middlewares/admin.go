@@ -0,0 +1,20 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminOnly → pasang setelah AuthMiddleware, tolak kalau role bukan admin
+func AdminOnly() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, _ := c.Get("role")
+		if role != "admin" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden, admin only"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
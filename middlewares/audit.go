@@ -0,0 +1,80 @@
+package middlewares
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"airplane_booking_go/models"
+	"airplane_booking_go/services"
+	"airplane_booking_go/utils"
+)
+
+// Audit → catat request yang mengubah data (POST/PUT/PATCH/DELETE): siapa, route apa, entity mana,
+// field apa yang berubah. Pasang setelah AuthMiddleware biar actor-nya ketahuan.
+func Audit(logger *services.AuditLogger, target services.AuditTarget) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		started := time.Now()
+		id := c.Param(target.Param)
+		before := target.Snapshot(c.Request.Context(), id)
+
+		c.Next()
+
+		// handler create kasih tahu id dokumen barunya
+		if created := utils.GetAuditTarget(c); created != "" {
+			id = created
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		var changes []models.AuditChange
+		if c.Writer.Status() < http.StatusBadRequest {
+			changes = services.AuditDiff(before, target.Snapshot(ctx, id))
+		}
+
+		role, _ := c.Get("role")
+		actor, _ := c.Get("userId")
+		entry := models.AuditLog{
+			ActorID:   toString(actor),
+			ActorRole: toString(role),
+			Action:    c.Request.Method + " " + c.FullPath(),
+			Entity:    target.Entity,
+			EntityID:  id,
+			Changes:   changes,
+			Request: models.AuditRequest{
+				Method:     c.Request.Method,
+				Path:       c.Request.URL.Path,
+				StatusCode: c.Writer.Status(),
+				IP:         c.ClientIP(),
+				UserAgent:  c.Request.UserAgent(),
+				RequestID:  c.GetHeader("X-Request-ID"),
+				DurationMs: time.Since(started).Milliseconds(),
+			},
+			At: started,
+		}
+		if err := logger.Record(ctx, entry); err != nil {
+			log.Printf("audit: record %s %s: %v\n", entry.Action, id, err)
+		}
+	}
+}
+
+func toString(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,146 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"airplane_booking_go/models"
+)
+
+const IdempotencyHeader = "Idempotency-Key"
+
+// responseRecorder → simpan copy body response biar bisa di-replay
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency → request dengan Idempotency-Key yang sama di-replay dari hasil pertama.
+// Harus dipasang setelah AuthMiddleware karena key di-scope per user.
+func Idempotency(collection *mongo.Collection) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > 255 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key is too long"})
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		userID, _ := c.Get("userId")
+		sum := sha256.Sum256([]byte(c.Request.Method + " " + c.Request.URL.Path + "\n" + string(body)))
+
+		record := models.IdempotencyRecord{
+			ID:          primitive.NewObjectID(),
+			Key:         key,
+			UserID:      fmt.Sprint(userID),
+			Method:      c.Request.Method,
+			Path:        c.Request.URL.Path,
+			Fingerprint: hex.EncodeToString(sum[:]),
+			Status:      "processing",
+			CreatedAt:   time.Now(),
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if _, err := collection.InsertOne(ctx, record); err != nil {
+			if !mongo.IsDuplicateKeyError(err) {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to store idempotency key"})
+				c.Abort()
+				return
+			}
+			replayIdempotent(ctx, c, collection, record)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		// handler panic (ditelan Recovery) / error server → hapus key biar client bisa retry,
+		// kalau gak, key nyangkut "processing" sampai TTL
+		finished := false
+		defer func() {
+			if finished {
+				return
+			}
+			cleanupCtx, cleanupCancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cleanupCancel()
+			collection.DeleteOne(cleanupCtx, bson.M{"_id": record.ID})
+		}()
+
+		c.Next()
+
+		if recorder.Status() >= http.StatusInternalServerError {
+			return
+		}
+		finished = true
+
+		saveCtx, saveCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer saveCancel()
+
+		now := time.Now()
+		collection.UpdateOne(saveCtx, bson.M{"_id": record.ID}, bson.M{"$set": bson.M{
+			"status":         "completed",
+			"responseStatus": recorder.Status(),
+			"responseBody":   recorder.body.Bytes(),
+			"contentType":    recorder.Header().Get("Content-Type"),
+			"completedAt":    now,
+		}})
+	}
+}
+
+func replayIdempotent(ctx context.Context, c *gin.Context, collection *mongo.Collection, record models.IdempotencyRecord) {
+	defer c.Abort()
+
+	var existing models.IdempotencyRecord
+	err := collection.FindOne(ctx, bson.M{"userId": record.UserID, "key": record.Key}).Decode(&existing)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch idempotency key"})
+		return
+	}
+
+	if existing.Fingerprint != record.Fingerprint {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Idempotency-Key was already used with a different request"})
+		return
+	}
+	if existing.Status != "completed" {
+		c.JSON(http.StatusConflict, gin.H{"error": "a request with this Idempotency-Key is still being processed"})
+		return
+	}
+
+	c.Header("Idempotent-Replayed", "true")
+	c.Data(existing.ResponseStatus, existing.ContentType, existing.ResponseBody)
+}
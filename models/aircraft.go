@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Aircraft → tipe pesawat + template denah kabin, dipakai ulang waktu bikin flight
+type Aircraft struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	TypeCode     string             `bson:"typeCode" json:"typeCode"` // ex: "A320", "B738"
+	Name         string             `bson:"name" json:"name"`
+	Manufacturer string             `bson:"manufacturer,omitempty" json:"manufacturer,omitempty"`
+	Layout       CabinLayout        `bson:"layout" json:"layout"` // harga di Cabin.Price diabaikan, fare diisi per flight
+	CreatedAt    time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt    time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Airline → data referensi maskapai, unik per IATA code. Flight simpan CarrierCode + nama (snapshot).
+type Airline struct {
+	ID              primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	IATA            string             `bson:"iata" json:"iata"`                     // ex: "GA", prefix nomor penerbangan
+	ICAO            string             `bson:"icao,omitempty" json:"icao,omitempty"` // ex: "GIA"
+	Name            string             `bson:"name" json:"name"`
+	LogoURL         string             `bson:"logoUrl,omitempty" json:"logoUrl,omitempty"`
+	BaggagePolicies []BaggagePolicy    `bson:"baggagePolicies,omitempty" json:"baggagePolicies,omitempty"`
+	CreatedAt       time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt       time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// BaggagePolicy → jatah bagasi per class
+type BaggagePolicy struct {
+	Class           string  `bson:"class" json:"class"` // economy, business, first
+	CabinKg         float64 `bson:"cabinKg" json:"cabinKg"`
+	CheckedPieces   int     `bson:"checkedPieces" json:"checkedPieces"`
+	CheckedKgPerBag float64 `bson:"checkedKgPerBag" json:"checkedKgPerBag"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Airport → data bandara yang ikut disimpan (snapshot) di Flight / FlightSchedule
+type Airport struct {
+	Code      string  `bson:"code" json:"code"`
+	Name      string  `bson:"name" json:"name"`
+	City      string  `bson:"city" json:"city"`
+	Country   string  `bson:"country" json:"country"`
+	TimeZone  string  `bson:"timeZone,omitempty" json:"timeZone,omitempty"` // IANA, ex: "Asia/Jakarta"
+	Latitude  float64 `bson:"latitude,omitempty" json:"latitude,omitempty"`
+	Longitude float64 `bson:"longitude,omitempty" json:"longitude,omitempty"`
+}
+
+// AirportRecord → data referensi bandara di collection airports, unik per IATA code.
+// Flight baru ambil Airport dari sini, flight lama tetap pakai snapshot-nya sendiri.
+type AirportRecord struct {
+	ID                   primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Airport              `bson:",inline"`
+	ICAO                 string    `bson:"icao,omitempty" json:"icao,omitempty"`                                 // ex: "WIII"
+	Type                 string    `bson:"type,omitempty" json:"type,omitempty"`                                 // large_airport, medium_airport, ... (OurAirports)
+	MinConnectionMinutes int       `bson:"minConnectionMinutes,omitempty" json:"minConnectionMinutes,omitempty"` // waktu transit minimum, 0 = default minConnectionTime
+	CreatedAt            time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt            time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+// Location → zona waktu bandara, nil kalau belum diisi (data lama) / gak valid
+func (a Airport) Location() *time.Location {
+	if a.TimeZone == "" {
+		return nil
+	}
+	loc, err := time.LoadLocation(a.TimeZone)
+	if err != nil {
+		return nil
+	}
+	return loc
+}
+
+// LocalTime → t dalam jam lokal bandara (RFC3339, offset ikut), kosong kalau zona waktunya gak diketahui
+func (a Airport) LocalTime(t time.Time) string {
+	loc := a.Location()
+	if loc == nil || t.IsZero() {
+		return ""
+	}
+	return t.In(loc).Format(time.RFC3339)
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuditLog → satu operasi yang mengubah data. Append-only: cuma pernah di-insert, gak pernah di-update / dihapus.
+type AuditLog struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	ActorID   string             `bson:"actorId" json:"actorId"`
+	ActorRole string             `bson:"actorRole" json:"actorRole"`
+	Action    string             `bson:"action" json:"action"` // method + route, ex: "PUT /flights/:id"
+	Entity    string             `bson:"entity" json:"entity"` // flight, booking, aircraft, ...
+	EntityID  string             `bson:"entityId,omitempty" json:"entityId,omitempty"`
+	Changes   []AuditChange      `bson:"changes,omitempty" json:"changes,omitempty"`
+	Request   AuditRequest       `bson:"request" json:"request"`
+	At        time.Time          `bson:"at" json:"at"`
+}
+
+// AuditChange → satu field yang berubah (nil = belum ada / dihapus)
+type AuditChange struct {
+	Field  string      `bson:"field" json:"field"`
+	Before interface{} `bson:"before,omitempty" json:"before,omitempty"`
+	After  interface{} `bson:"after,omitempty" json:"after,omitempty"`
+}
+
+// AuditRequest → metadata request asal perubahan
+type AuditRequest struct {
+	Method     string `bson:"method" json:"method"`
+	Path       string `bson:"path" json:"path"`
+	StatusCode int    `bson:"statusCode" json:"statusCode"`
+	IP         string `bson:"ip" json:"ip"`
+	UserAgent  string `bson:"userAgent,omitempty" json:"userAgent,omitempty"`
+	RequestID  string `bson:"requestId,omitempty" json:"requestId,omitempty"`
+	DurationMs int64  `bson:"durationMs" json:"durationMs"`
+}
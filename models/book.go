@@ -7,12 +7,41 @@ import (
 )
 
 type Booking struct {
-	ID         primitive.ObjectID   `bson:"_id,omitempty" json:"id,omitempty"`
-	UserID     primitive.ObjectID   `bson:"userId" json:"userId"`
-	FlightID   primitive.ObjectID   `bson:"flightId" json:"flightId"`
-	Seats      []Seat             	`bson:"seats" json:"seats"` // seat numbers, ex: ["12A", "12B"]
-	TotalPrice float64              `bson:"totalPrice" json:"totalPrice"`
-	Status     string               `bson:"status" json:"status"` // pending, confirmed, cancelled
-	CreatedAt  time.Time            `bson:"createdAt" json:"createdAt"`
-	UpdatedAt  time.Time            `bson:"updatedAt" json:"updatedAt"`
+	ID            primitive.ObjectID   `bson:"_id,omitempty" json:"id,omitempty"`
+	RecordLocator string               `bson:"recordLocator,omitempty" json:"recordLocator,omitempty"` // PNR 6 karakter, ex: "K7QX2M"
+	UserID        primitive.ObjectID   `bson:"userId" json:"userId"`
+	FlightID      primitive.ObjectID   `bson:"flightId" json:"flightId"`
+	Seats         []Seat             	`bson:"seats" json:"seats"` // seat numbers, ex: ["12A", "12B"]
+	Segments      []BookingSegment     `bson:"segments,omitempty" json:"segments,omitempty"` // cuma ada di booking multi-flight, FlightID/Seats = segmen pertama
+	Passengers    []Passenger          `bson:"passengers" json:"passengers"` // satu penumpang per kursi
+	TotalPrice    float64              `bson:"totalPrice" json:"totalPrice"`
+	RefundAmount  float64              `bson:"refundAmount,omitempty" json:"refundAmount,omitempty"` // hasil cancellation policy waktu di-cancel
+	Status        BookingStatus        `bson:"status" json:"status"` // lihat booking_status.go
+	StatusHistory []StatusChange       `bson:"statusHistory,omitempty" json:"statusHistory,omitempty"`
+	Changes       []BookingChange      `bson:"changes,omitempty" json:"changes,omitempty"`
+	HoldExpiresAt *time.Time           `bson:"holdExpiresAt,omitempty" json:"holdExpiresAt,omitempty"` // batas waktu bayar, selama held/pending
+	PaymentID     *primitive.ObjectID  `bson:"paymentId,omitempty" json:"paymentId,omitempty"`
+	CreatedAt     time.Time            `bson:"createdAt" json:"createdAt"`
+	UpdatedAt     time.Time            `bson:"updatedAt" json:"updatedAt"`
+}
+
+// BookingSegment → satu flight di booking itinerary (round-trip / connecting).
+// Seats[i] milik Passengers[i].
+type BookingSegment struct {
+	FlightID primitive.ObjectID `bson:"flightId" json:"flightId"`
+	Seats    []Seat             `bson:"seats" json:"seats"`
+	SubTotal float64            `bson:"subTotal" json:"subTotal"`
+}
+
+// AllSegments → semua segmen booking, booking single-flight dianggap satu segmen
+func (b Booking) AllSegments() []BookingSegment {
+	if len(b.Segments) > 0 {
+		return b.Segments
+	}
+	return []BookingSegment{{FlightID: b.FlightID, Seats: b.Seats, SubTotal: b.TotalPrice}}
+}
+
+// IsItinerary → booking punya lebih dari satu flight
+func (b Booking) IsItinerary() bool {
+	return len(b.Segments) > 1
 }
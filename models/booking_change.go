@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// BookingChange → riwayat perubahan booking setelah dibuat (ganti kursi, ganti flight)
+type BookingChange struct {
+	Type           string              `bson:"type" json:"type"` // seat_change, flight_change
+	FromFlightID   primitive.ObjectID  `bson:"fromFlightId" json:"fromFlightId"`
+	ToFlightID     primitive.ObjectID  `bson:"toFlightId" json:"toFlightId"`
+	SeatSwaps      []SeatSwap          `bson:"seatSwaps" json:"seatSwaps"`
+	FareDifference float64             `bson:"fareDifference" json:"fareDifference"` // positif = user bayar, negatif = refund
+	ChangeFee      float64             `bson:"changeFee" json:"changeFee"`
+	PaymentID      *primitive.ObjectID `bson:"paymentId,omitempty" json:"paymentId,omitempty"`
+	Actor          string              `bson:"actor" json:"actor"`
+	At             time.Time           `bson:"at" json:"at"`
+}
+
+type SeatSwap struct {
+	From string `bson:"from" json:"from"`
+	To   string `bson:"to" json:"to"`
+}
@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+type BookingStatus string
+
+const (
+	BookingStatusPending   BookingStatus = "pending"
+	BookingStatusHeld      BookingStatus = "held"
+	BookingStatusConfirmed BookingStatus = "confirmed"
+	BookingStatusTicketed  BookingStatus = "ticketed"
+	BookingStatusCheckedIn BookingStatus = "checked-in"
+	BookingStatusFlown     BookingStatus = "flown"
+	BookingStatusCancelled BookingStatus = "cancelled"
+	BookingStatusRefunded  BookingStatus = "refunded"
+	BookingStatusExpired   BookingStatus = "expired"
+)
+
+// SeatHoldingStatuses → status booking yang masih pegang kursi di flight
+var SeatHoldingStatuses = []BookingStatus{
+	BookingStatusPending, BookingStatusHeld, BookingStatusConfirmed, BookingStatusTicketed, BookingStatusCheckedIn,
+}
+
+// transisi status yang diizinkan, selain ini ditolak
+var bookingTransitions = map[BookingStatus][]BookingStatus{
+	BookingStatusPending:   {BookingStatusHeld, BookingStatusConfirmed, BookingStatusCancelled, BookingStatusExpired},
+	BookingStatusHeld:      {BookingStatusPending, BookingStatusConfirmed, BookingStatusCancelled, BookingStatusExpired},
+	BookingStatusConfirmed: {BookingStatusTicketed, BookingStatusCancelled},
+	BookingStatusTicketed:  {BookingStatusCheckedIn, BookingStatusFlown, BookingStatusCancelled},
+	BookingStatusCheckedIn: {BookingStatusFlown, BookingStatusCancelled},
+	BookingStatusCancelled: {BookingStatusRefunded},
+}
+
+// CanTransitionTo → cek apakah status boleh pindah ke next
+func (s BookingStatus) CanTransitionTo(next BookingStatus) bool {
+	for _, allowed := range bookingTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValid → status dikenal atau tidak
+func (s BookingStatus) IsValid() bool {
+	switch s {
+	case BookingStatusPending, BookingStatusHeld, BookingStatusConfirmed, BookingStatusTicketed,
+		BookingStatusCheckedIn, BookingStatusFlown, BookingStatusCancelled, BookingStatusRefunded, BookingStatusExpired:
+		return true
+	}
+	return false
+}
+
+// AwaitingPayment → booking masih ngunci kursi tapi belum dibayar
+func (s BookingStatus) AwaitingPayment() bool {
+	return s == BookingStatusPending || s == BookingStatusHeld
+}
+
+// StatusChange → satu entry di statusHistory booking
+type StatusChange struct {
+	From   BookingStatus `bson:"from,omitempty" json:"from,omitempty"`
+	To     BookingStatus `bson:"to" json:"to"`
+	Actor  string        `bson:"actor" json:"actor"` // userId, atau "system" buat job background
+	Reason string        `bson:"reason,omitempty" json:"reason,omitempty"`
+	At     time.Time     `bson:"at" json:"at"`
+}
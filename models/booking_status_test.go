@@ -0,0 +1,54 @@
+package models
+
+import "testing"
+
+var allBookingStatuses = []BookingStatus{
+	BookingStatusPending, BookingStatusHeld, BookingStatusConfirmed, BookingStatusTicketed, BookingStatusCheckedIn,
+	BookingStatusFlown, BookingStatusCancelled, BookingStatusRefunded, BookingStatusExpired,
+}
+
+func TestBookingStatusTransitions(t *testing.T) {
+	// semua pasangan from → to yang boleh, sisanya harus ditolak
+	allowed := map[BookingStatus][]BookingStatus{
+		BookingStatusPending:   {BookingStatusHeld, BookingStatusConfirmed, BookingStatusCancelled, BookingStatusExpired},
+		BookingStatusHeld:      {BookingStatusPending, BookingStatusConfirmed, BookingStatusCancelled, BookingStatusExpired},
+		BookingStatusConfirmed: {BookingStatusTicketed, BookingStatusCancelled},
+		BookingStatusTicketed:  {BookingStatusCheckedIn, BookingStatusFlown, BookingStatusCancelled},
+		BookingStatusCheckedIn: {BookingStatusFlown, BookingStatusCancelled},
+		BookingStatusCancelled: {BookingStatusRefunded},
+	}
+
+	for _, from := range allBookingStatuses {
+		want := map[BookingStatus]bool{}
+		for _, to := range allowed[from] {
+			want[to] = true
+		}
+		for _, to := range allBookingStatuses {
+			if got := from.CanTransitionTo(to); got != want[to] {
+				t.Errorf("%s.CanTransitionTo(%s) = %v, want %v", from, to, got, want[to])
+			}
+		}
+	}
+}
+
+func TestBookingStatusIsValid(t *testing.T) {
+	for _, status := range allBookingStatuses {
+		if !status.IsValid() {
+			t.Errorf("%s.IsValid() = false, want true", status)
+		}
+	}
+	for _, status := range []BookingStatus{"", "paid", "CONFIRMED"} {
+		if status.IsValid() {
+			t.Errorf("%q.IsValid() = true, want false", status)
+		}
+	}
+}
+
+func TestBookingStatusAwaitingPayment(t *testing.T) {
+	for _, status := range allBookingStatuses {
+		want := status == BookingStatusPending || status == BookingStatusHeld
+		if got := status.AwaitingPayment(); got != want {
+			t.Errorf("%s.AwaitingPayment() = %v, want %v", status, got, want)
+		}
+	}
+}
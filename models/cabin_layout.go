@@ -0,0 +1,31 @@
+package models
+
+// CabinLayout → denah kabin pesawat, dipakai buat generate kursi "12A", "12B", dst
+type CabinLayout struct {
+	Cabins   []Cabin  `bson:"cabins" json:"cabins"`
+	ExitRows []int    `bson:"exitRows,omitempty" json:"exitRows,omitempty"` // baris pintu darurat, otomatis extra legroom
+	WingRows RowRange `bson:"wingRows,omitempty" json:"wingRows,omitempty"` // baris di atas sayap
+}
+
+// Cabin → satu class di kabin, ex: economy baris 10-35 dengan susunan "ABC-DEF"
+type Cabin struct {
+	Class            string   `bson:"class" json:"class"`
+	FirstRow         int      `bson:"firstRow" json:"firstRow"`
+	LastRow          int      `bson:"lastRow" json:"lastRow"`
+	SeatLetters      string   `bson:"seatLetters" json:"seatLetters"` // huruf kursi per baris, "-" = lorong
+	Price            float64  `bson:"price,omitempty" json:"price,omitempty"`
+	ExtraLegroomRows []int    `bson:"extraLegroomRows,omitempty" json:"extraLegroomRows,omitempty"`
+	BlockedSeats     []string `bson:"blockedSeats,omitempty" json:"blockedSeats,omitempty"` // ex: ["12A"], gak dijual
+	SkipRows         []int    `bson:"skipRows,omitempty" json:"skipRows,omitempty"`         // ex: baris 13 yang gak ada
+}
+
+type RowRange struct {
+	From int `bson:"from,omitempty" json:"from,omitempty"`
+	To   int `bson:"to,omitempty" json:"to,omitempty"`
+}
+
+const (
+	SeatPositionWindow = "window"
+	SeatPositionAisle  = "aisle"
+	SeatPositionMiddle = "middle"
+)
@@ -9,15 +9,29 @@ import (
 
 type Flight struct {
 	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
-	Airline       string             `bson:"airline" json:"airline"`
+	Airline       string             `bson:"airline" json:"airline"` // nama maskapai (snapshot dari collection airlines)
+	CarrierCode   string             `bson:"carrierCode,omitempty" json:"carrierCode,omitempty"` // IATA maskapai, ex: "GA"
+	AirlineLogoURL string            `bson:"airlineLogoUrl,omitempty" json:"airlineLogoUrl,omitempty"`
 	FlightNumber  string             `bson:"flightNumber" json:"flightNumber"`
+	AircraftType  string             `bson:"aircraftType,omitempty" json:"aircraftType,omitempty"`
 	Departure     Airport            `bson:"departure" json:"departure"`
 	Arrival       Airport            `bson:"arrival" json:"arrival"`
 	DepartureTime time.Time          `bson:"departureTime" json:"departureTime"`
 	ArrivalTime   time.Time          `bson:"arrivalTime" json:"arrivalTime"`
+	DepartureLocalTime string        `bson:"-" json:"departureLocalTime,omitempty"` // jam lokal bandara asal, cuma di response (lihat WithLocalTimes)
+	ArrivalLocalTime   string        `bson:"-" json:"arrivalLocalTime,omitempty"`
 	Duration      int                `bson:"duration" json:"duration"`
 	MinPrice      float64            `bson:"minPrice" json:"minPrice"`
+	Status        FlightStatus       `bson:"status,omitempty" json:"status"` // lihat flight_status.go, kosong = scheduled (data lama)
+	StatusHistory []FlightStatusChange `bson:"statusHistory,omitempty" json:"statusHistory,omitempty"`
+	EstimatedDepartureTime *time.Time `bson:"estimatedDepartureTime,omitempty" json:"estimatedDepartureTime,omitempty"`
+	EstimatedArrivalTime   *time.Time `bson:"estimatedArrivalTime,omitempty" json:"estimatedArrivalTime,omitempty"`
+	ActualDepartureTime    *time.Time `bson:"actualDepartureTime,omitempty" json:"actualDepartureTime,omitempty"`
+	ActualArrivalTime      *time.Time `bson:"actualArrivalTime,omitempty" json:"actualArrivalTime,omitempty"`
 	Seats         []Seat             `bson:"seats" json:"seats"`
+	CabinLayout   *CabinLayout       `bson:"cabinLayout,omitempty" json:"cabinLayout,omitempty"`
+	ScheduleID    *primitive.ObjectID `bson:"scheduleId,omitempty" json:"scheduleId,omitempty"` // diisi kalau dibuat dari jadwal rutin
+	ServiceDate   string             `bson:"serviceDate,omitempty" json:"serviceDate,omitempty"` // tanggal lokal keberangkatan, "2006-01-02"
 	CreatedAt     time.Time          `bson:"createdAt" json:"createdAt"`
 	UpdatedAt     time.Time          `bson:"updatedAt" json:"updatedAt"`
 }
@@ -26,12 +40,32 @@ type Seat struct {
 	Number      string  `bson:"number" json:"number"`
 	Class       string  `bson:"class" json:"class"` 
 	IsAvailable bool    `bson:"isAvailable" json:"isAvailable"`
+	Blocked     bool    `bson:"blocked,omitempty" json:"blocked,omitempty"` // kursi gak dijual (crew rest, rusak, dll)
 	Price       float64 `bson:"price" json:"price"`
+	Row          int    `bson:"row,omitempty" json:"row,omitempty"`
+	Column       int    `bson:"column,omitempty" json:"column,omitempty"` // posisi fisik dari kiri, lorong ikut dihitung
+	Position     string `bson:"position,omitempty" json:"position,omitempty"` // window, aisle, middle
+	ExtraLegroom bool   `bson:"extraLegroom,omitempty" json:"extraLegroom,omitempty"`
+	ExitRow      bool   `bson:"exitRow,omitempty" json:"exitRow,omitempty"`
+	OverWing     bool   `bson:"overWing,omitempty" json:"overWing,omitempty"`
 }
 
-type Airport struct {
-	Code    string `bson:"code" json:"code"`
-	Name    string `bson:"name" json:"name"`
-	City    string `bson:"city" json:"city"`
-	Country string `bson:"country" json:"country"`
+// CurrentStatus → status operasional, flight lama yang belum punya status dianggap scheduled
+func (f Flight) CurrentStatus() FlightStatus {
+	if f.Status == "" {
+		return FlightStatusScheduled
+	}
+	return f.Status
+}
+
+// WithLocalTimes → flight dengan DepartureLocalTime / ArrivalLocalTime terisi, buat response
+func (f Flight) WithLocalTimes() Flight {
+	f.DepartureLocalTime = f.Departure.LocalTime(f.DepartureTime)
+	f.ArrivalLocalTime = f.Arrival.LocalTime(f.ArrivalTime)
+	return f
+}
+
+// IsBookable → kursi kosong dan gak diblok
+func (s Seat) IsBookable() bool {
+	return s.IsAvailable && !s.Blocked
 }
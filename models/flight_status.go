@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+type FlightStatus string
+
+const (
+	FlightStatusScheduled FlightStatus = "scheduled"
+	FlightStatusBoarding  FlightStatus = "boarding"
+	FlightStatusDelayed   FlightStatus = "delayed"
+	FlightStatusDeparted  FlightStatus = "departed"
+	FlightStatusArrived   FlightStatus = "arrived"
+	FlightStatusCancelled FlightStatus = "cancelled"
+	FlightStatusDiverted  FlightStatus = "diverted"
+)
+
+// transisi status operasional yang diizinkan, arrived/cancelled/diverted = final
+var flightTransitions = map[FlightStatus][]FlightStatus{
+	FlightStatusScheduled: {FlightStatusBoarding, FlightStatusDelayed, FlightStatusDeparted, FlightStatusCancelled},
+	FlightStatusDelayed:   {FlightStatusBoarding, FlightStatusDeparted, FlightStatusCancelled},
+	FlightStatusBoarding:  {FlightStatusDelayed, FlightStatusDeparted, FlightStatusCancelled},
+	FlightStatusDeparted:  {FlightStatusArrived, FlightStatusDiverted},
+}
+
+// CanTransitionTo → cek apakah status flight boleh pindah ke next
+func (s FlightStatus) CanTransitionTo(next FlightStatus) bool {
+	for _, allowed := range flightTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinal → status akhir, gak bisa diubah lagi
+func (s FlightStatus) IsFinal() bool {
+	return len(flightTransitions[s]) == 0
+}
+
+// IsValid → status dikenal atau tidak
+func (s FlightStatus) IsValid() bool {
+	switch s {
+	case FlightStatusScheduled, FlightStatusBoarding, FlightStatusDelayed, FlightStatusDeparted,
+		FlightStatusArrived, FlightStatusCancelled, FlightStatusDiverted:
+		return true
+	}
+	return false
+}
+
+// AcceptsBookings → flight yang sudah berangkat / batal gak bisa dibooking lagi
+func (s FlightStatus) AcceptsBookings() bool {
+	switch s {
+	case FlightStatusDeparted, FlightStatusArrived, FlightStatusCancelled, FlightStatusDiverted:
+		return false
+	}
+	return true
+}
+
+// FlightStatusChange → satu entry di statusHistory flight
+type FlightStatusChange struct {
+	From   FlightStatus `bson:"from,omitempty" json:"from,omitempty"`
+	To     FlightStatus `bson:"to" json:"to"`
+	Actor  string       `bson:"actor" json:"actor"`
+	Reason string       `bson:"reason,omitempty" json:"reason,omitempty"`
+	At     time.Time    `bson:"at" json:"at"`
+}
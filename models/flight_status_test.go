@@ -0,0 +1,71 @@
+package models
+
+import "testing"
+
+var allFlightStatuses = []FlightStatus{
+	FlightStatusScheduled, FlightStatusBoarding, FlightStatusDelayed, FlightStatusDeparted,
+	FlightStatusArrived, FlightStatusCancelled, FlightStatusDiverted,
+}
+
+func TestFlightStatusTransitions(t *testing.T) {
+	// semua pasangan from → to yang boleh, sisanya harus ditolak
+	allowed := map[FlightStatus][]FlightStatus{
+		FlightStatusScheduled: {FlightStatusBoarding, FlightStatusDelayed, FlightStatusDeparted, FlightStatusCancelled},
+		FlightStatusDelayed:   {FlightStatusBoarding, FlightStatusDeparted, FlightStatusCancelled},
+		FlightStatusBoarding:  {FlightStatusDelayed, FlightStatusDeparted, FlightStatusCancelled},
+		FlightStatusDeparted:  {FlightStatusArrived, FlightStatusDiverted},
+	}
+
+	for _, from := range allFlightStatuses {
+		want := map[FlightStatus]bool{}
+		for _, to := range allowed[from] {
+			want[to] = true
+		}
+		for _, to := range allFlightStatuses {
+			if got := from.CanTransitionTo(to); got != want[to] {
+				t.Errorf("%s.CanTransitionTo(%s) = %v, want %v", from, to, got, want[to])
+			}
+		}
+	}
+}
+
+func TestFlightStatusFlags(t *testing.T) {
+	tests := []struct {
+		status          FlightStatus
+		final           bool
+		acceptsBookings bool
+	}{
+		{FlightStatusScheduled, false, true},
+		{FlightStatusBoarding, false, true},
+		{FlightStatusDelayed, false, true},
+		{FlightStatusDeparted, false, false},
+		{FlightStatusArrived, true, false},
+		{FlightStatusCancelled, true, false},
+		{FlightStatusDiverted, true, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.status.IsFinal(); got != tt.final {
+			t.Errorf("%s.IsFinal() = %v, want %v", tt.status, got, tt.final)
+		}
+		if got := tt.status.AcceptsBookings(); got != tt.acceptsBookings {
+			t.Errorf("%s.AcceptsBookings() = %v, want %v", tt.status, got, tt.acceptsBookings)
+		}
+		if !tt.status.IsValid() {
+			t.Errorf("%s.IsValid() = false, want true", tt.status)
+		}
+	}
+	if FlightStatus("landed").IsValid() {
+		t.Error(`"landed".IsValid() = true, want false`)
+	}
+}
+
+func TestFlightCurrentStatus(t *testing.T) {
+	// flight lama tanpa status dianggap scheduled
+	if got := (Flight{}).CurrentStatus(); got != FlightStatusScheduled {
+		t.Errorf("CurrentStatus() = %s, want %s", got, FlightStatusScheduled)
+	}
+	if got := (Flight{Status: FlightStatusDelayed}).CurrentStatus(); got != FlightStatusDelayed {
+		t.Errorf("CurrentStatus() = %s, want %s", got, FlightStatusDelayed)
+	}
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// IdempotencyRecord → hasil request yang pakai header Idempotency-Key, dihapus otomatis via TTL index
+type IdempotencyRecord struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Key            string             `bson:"key" json:"key"`
+	UserID         string             `bson:"userId" json:"userId"`
+	Method         string             `bson:"method" json:"method"`
+	Path           string             `bson:"path" json:"path"`
+	Fingerprint    string             `bson:"fingerprint" json:"fingerprint"` // sha256 dari method + path + body
+	Status         string             `bson:"status" json:"status"`           // processing, completed
+	ResponseStatus int                `bson:"responseStatus,omitempty" json:"responseStatus,omitempty"`
+	ResponseBody   []byte             `bson:"responseBody,omitempty" json:"responseBody,omitempty"`
+	ContentType    string             `bson:"contentType,omitempty" json:"contentType,omitempty"`
+	CreatedAt      time.Time          `bson:"createdAt" json:"createdAt"`
+	CompletedAt    *time.Time         `bson:"completedAt,omitempty" json:"completedAt,omitempty"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Notification → satu pesan di antrian notifikasi (per channel), dikirim ulang sampai MaxAttempts
+type Notification struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	EventID       string             `bson:"eventId,omitempty" json:"eventId,omitempty"` // domain event asal (outbox), buat dedup
+	Event         string             `bson:"event" json:"event"`                         // ex: booking.confirmed
+	Channel       string             `bson:"channel" json:"channel"`                     // email, file, log
+	UserID        primitive.ObjectID `bson:"userId" json:"userId"`
+	BookingID     primitive.ObjectID `bson:"bookingId,omitempty" json:"bookingId,omitempty"`
+	To            string             `bson:"to" json:"to"`
+	Locale        string             `bson:"locale" json:"locale"`
+	Subject       string             `bson:"subject" json:"subject"`
+	Body          string             `bson:"body" json:"body"`
+	Status        string             `bson:"status" json:"status"` // pending, sent, failed
+	Attempts      int                `bson:"attempts" json:"attempts"`
+	NextAttemptAt time.Time          `bson:"nextAttemptAt" json:"nextAttemptAt"`
+	LastError     string             `bson:"lastError,omitempty" json:"lastError,omitempty"`
+	SentAt        *time.Time         `bson:"sentAt,omitempty" json:"sentAt,omitempty"`
+	CreatedAt     time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt     time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OutboxEvent → domain event yang ditulis dalam transaksi yang sama dengan perubahan datanya,
+// lalu dipublish oleh relay
+type OutboxEvent struct {
+	ID            primitive.ObjectID     `bson:"_id,omitempty" json:"id,omitempty"`
+	Type          string                 `bson:"type" json:"type"`                   // ex: booking.created
+	AggregateType string                 `bson:"aggregateType" json:"aggregateType"` // booking, flight
+	AggregateID   primitive.ObjectID     `bson:"aggregateId" json:"aggregateId"`
+	Data          map[string]interface{} `bson:"data,omitempty" json:"data,omitempty"`
+	Status        string                 `bson:"status" json:"status"` // pending, published, failed
+	Attempts      int                    `bson:"attempts" json:"attempts"`
+	NextAttemptAt time.Time              `bson:"nextAttemptAt" json:"nextAttemptAt"`
+	LastError     string                 `bson:"lastError,omitempty" json:"lastError,omitempty"`
+	PublishedAt   *time.Time             `bson:"publishedAt,omitempty" json:"publishedAt,omitempty"`
+	CreatedAt     time.Time              `bson:"createdAt" json:"createdAt"`
+}
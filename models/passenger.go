@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Passenger → penumpang untuk satu kursi di booking
+type Passenger struct {
+	FirstName   string         `bson:"firstName" json:"firstName"`
+	LastName    string         `bson:"lastName" json:"lastName"`
+	Gender      string         `bson:"gender" json:"gender"`
+	DateOfBirth time.Time      `bson:"dateOfBirth" json:"dateOfBirth"`
+	Nationality string         `bson:"nationality" json:"nationality"` // ISO 3166 alpha-2, ex: "ID"
+	Document    TravelDocument `bson:"document" json:"document"`
+	SeatNumber  string         `bson:"seatNumber" json:"seatNumber"`
+}
+
+type TravelDocument struct {
+	Type           string    `bson:"type" json:"type"` // passport, national_id
+	Number         string    `bson:"number" json:"number"`
+	IssuingCountry string    `bson:"issuingCountry" json:"issuingCountry"`
+	ExpiryDate     time.Time `bson:"expiryDate" json:"expiryDate"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Payment struct {
+	ID              primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	BookingID       primitive.ObjectID `bson:"bookingId" json:"bookingId"`
+	UserID          primitive.ObjectID `bson:"userId" json:"userId"`
+	Provider        string             `bson:"provider" json:"provider"`
+	Amount          float64            `bson:"amount" json:"amount"`
+	Currency        string             `bson:"currency" json:"currency"`
+	Status          string             `bson:"status" json:"status"` // captured, failed, partially_refunded, refunded
+	AuthorizationID string             `bson:"authorizationId,omitempty" json:"authorizationId,omitempty"`
+	CaptureID       string             `bson:"captureId,omitempty" json:"captureId,omitempty"`
+	RefundedAmount  float64            `bson:"refundedAmount" json:"refundedAmount"`
+	Refunds         []PaymentRefund    `bson:"refunds,omitempty" json:"refunds,omitempty"`
+	FailureReason   string             `bson:"failureReason,omitempty" json:"failureReason,omitempty"`
+	CreatedAt       time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt       time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+type PaymentRefund struct {
+	ID        string    `bson:"id" json:"id"`
+	Amount    float64   `bson:"amount" json:"amount"`
+	Reason    string    `bson:"reason" json:"reason"`
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FlightSchedule → jadwal rutin, generator bikin Flight per tanggal dari sini
+type FlightSchedule struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Airline        string             `bson:"airline" json:"airline"`
+	CarrierCode    string             `bson:"carrierCode,omitempty" json:"carrierCode,omitempty"`
+	AirlineLogoURL string             `bson:"airlineLogoUrl,omitempty" json:"airlineLogoUrl,omitempty"`
+	FlightNumber   string             `bson:"flightNumber" json:"flightNumber"`
+	Departure      Airport            `bson:"departure" json:"departure"`
+	Arrival        Airport            `bson:"arrival" json:"arrival"`
+	DepartureTime  string             `bson:"departureTime" json:"departureTime"` // jam lokal bandara asal, "HH:MM"
+	Duration       int                `bson:"duration" json:"duration"`           // menit
+	TimeZone       string             `bson:"timeZone" json:"timeZone"`           // IANA, ex: "Asia/Jakarta"
+	DaysOfWeek     []int              `bson:"daysOfWeek" json:"daysOfWeek"`       // 1 = Senin ... 7 = Minggu
+	ValidFrom      string             `bson:"validFrom" json:"validFrom"`         // "2006-01-02", tanggal lokal
+	ValidTo        string             `bson:"validTo" json:"validTo"`
+	AircraftType   string             `bson:"aircraftType" json:"aircraftType"`
+	Fares          map[string]float64 `bson:"fares" json:"fares"` // class → harga
+	Active         bool               `bson:"active" json:"active"`
+	CreatedAt      time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt      time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
@@ -13,6 +13,7 @@ type User struct{
 	Password	string				`bson:"password" json:"password"`
 	Phone		string				`bson:"phone" json:"phone"`
 	Role		string				`bson:"role,omitempty" json:"role,omitempty"`
+	Locale		string				`bson:"locale,omitempty" json:"locale,omitempty"` // bahasa notifikasi, ex: "id", "en"
 	CreatedAt	time.Time			`bson:"created_at" json:"created_at"`
 	UpdatedAt	time.Time			`bson:"updated_at" json:"updated_at"`
 }
\ No newline at end of file
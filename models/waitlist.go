@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WaitlistEntry → antrian kursi untuk flight + class yang sudah penuh
+type WaitlistEntry struct {
+	ID             primitive.ObjectID  `bson:"_id,omitempty" json:"id,omitempty"`
+	FlightID       primitive.ObjectID  `bson:"flightId" json:"flightId"`
+	Class          string              `bson:"class" json:"class"`
+	UserID         primitive.ObjectID  `bson:"userId" json:"userId"`
+	SeatCount      int                 `bson:"seatCount" json:"seatCount"`
+	Status         string              `bson:"status" json:"status"` // waiting, offered, claimed, expired, cancelled
+	OfferedSeats   []string            `bson:"offeredSeats,omitempty" json:"offeredSeats,omitempty"`
+	OfferExpiresAt *time.Time          `bson:"offerExpiresAt,omitempty" json:"offerExpiresAt,omitempty"`
+	BookingID      *primitive.ObjectID `bson:"bookingId,omitempty" json:"bookingId,omitempty"` // booking hasil claim
+	CreatedAt      time.Time           `bson:"createdAt" json:"createdAt"`
+	UpdatedAt      time.Time           `bson:"updatedAt" json:"updatedAt"`
+}
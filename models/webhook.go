@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WebhookSubscription → endpoint sistem luar (CRM, accounting) yang mau dikabari event tertentu
+type WebhookSubscription struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	URL         string             `bson:"url" json:"url"`
+	Events      []string           `bson:"events" json:"events"`           // nama event, "*" = semua
+	Secret      string             `bson:"secret" json:"secret,omitempty"` // kunci HMAC, cuma ditampilkan waktu dibuat
+	Description string             `bson:"description,omitempty" json:"description,omitempty"`
+	Active      bool               `bson:"active" json:"active"`
+	CreatedAt   time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// Matches → subscription ini mau event tersebut
+func (s WebhookSubscription) Matches(event string) bool {
+	for _, e := range s.Events {
+		if e == "*" || e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery → satu event untuk satu subscription, sekaligus log semua percobaan kirimnya
+type WebhookDelivery struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	SubscriptionID primitive.ObjectID `bson:"subscriptionId" json:"subscriptionId"`
+	EventID        string             `bson:"eventId" json:"eventId"`
+	Event          string             `bson:"event" json:"event"`
+	URL            string             `bson:"url" json:"url"`
+	Payload        string             `bson:"payload" json:"payload"` // body JSON persis seperti yang ditandatangani
+	Status         string             `bson:"status" json:"status"`   // pending, delivered, dead
+	Attempts       int                `bson:"attempts" json:"attempts"`
+	NextAttemptAt  time.Time          `bson:"nextAttemptAt" json:"nextAttemptAt"`
+	History        []WebhookAttempt   `bson:"history,omitempty" json:"history,omitempty"`
+	LastError      string             `bson:"lastError,omitempty" json:"lastError,omitempty"`
+	DeliveredAt    *time.Time         `bson:"deliveredAt,omitempty" json:"deliveredAt,omitempty"`
+	CreatedAt      time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt      time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// WebhookAttempt → hasil satu kali kirim
+type WebhookAttempt struct {
+	At         time.Time `bson:"at" json:"at"`
+	StatusCode int       `bson:"statusCode,omitempty" json:"statusCode,omitempty"`
+	Error      string    `bson:"error,omitempty" json:"error,omitempty"`
+	DurationMs int64     `bson:"durationMs" json:"durationMs"`
+}
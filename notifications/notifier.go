@@ -0,0 +1,23 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrNoRecipient = errors.New("notification has no recipient")
+
+// Notifier → satu channel pengiriman notifikasi (email, file, log, nanti bisa SMS/push)
+type Notifier interface {
+	Channel() string
+	Send(ctx context.Context, msg Message) error
+}
+
+// Message → notifikasi yang sudah di-render dari template
+type Message struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+	Event   string `json:"event"`
+	Locale  string `json:"locale"`
+}
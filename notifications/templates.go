@@ -0,0 +1,195 @@
+package notifications
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// event yang dikirim ke user
+const (
+	EventBookingConfirmed    = "booking.confirmed"
+	EventBookingCancelled    = "booking.cancelled"
+	EventBookingRebooked     = "booking.rebooked"
+	EventFlightStatusChanged = "flight.status_changed"
+	EventFlightRescheduled   = "flight.rescheduled"
+	EventFlightReseated      = "flight.reseated"
+)
+
+// Template → subject + body (text/template), data dari map yang dikirim waktu enqueue
+type Template struct {
+	Subject string
+	Body    string
+}
+
+// template bawaan, bisa ditimpa file di notificationTemplateDir
+var defaultTemplates = map[string]map[string]Template{
+	EventBookingConfirmed: {
+		"en": {
+			Subject: "Booking {{.RecordLocator}} confirmed",
+			Body: "Hi {{.Name}},\n\nYour booking {{.RecordLocator}} on {{.FlightNumber}} ({{.From}} → {{.To}}) departing {{.DepartureTime}} is confirmed.\n" +
+				"Seats: {{.Seats}}\nTotal paid: {{.Currency}} {{.TotalPrice}}\n",
+		},
+		"id": {
+			Subject: "Booking {{.RecordLocator}} terkonfirmasi",
+			Body: "Halo {{.Name}},\n\nBooking {{.RecordLocator}} untuk {{.FlightNumber}} ({{.From}} → {{.To}}) berangkat {{.DepartureTime}} sudah terkonfirmasi.\n" +
+				"Kursi: {{.Seats}}\nTotal dibayar: {{.Currency}} {{.TotalPrice}}\n",
+		},
+	},
+	EventBookingCancelled: {
+		"en": {
+			Subject: "Booking {{.RecordLocator}} cancelled",
+			Body:    "Hi {{.Name}},\n\nYour booking {{.RecordLocator}} on {{.FlightNumber}} has been cancelled.{{if .Reason}} {{.Reason}}{{end}}\n{{if .Refunded}}Refund: {{.Currency}} {{.Refunded}}\n{{end}}",
+		},
+		"id": {
+			Subject: "Booking {{.RecordLocator}} dibatalkan",
+			Body:    "Halo {{.Name}},\n\nBooking {{.RecordLocator}} untuk {{.FlightNumber}} sudah dibatalkan.{{if .Reason}} {{.Reason}}{{end}}\n{{if .Refunded}}Refund: {{.Currency}} {{.Refunded}}\n{{end}}",
+		},
+	},
+	EventBookingRebooked: {
+		"en": {
+			Subject: "Booking {{.RecordLocator}} moved to {{.NewFlightNumber}}",
+			Body:    "Hi {{.Name}},\n\nFlight {{.FlightNumber}} was cancelled. Your booking {{.RecordLocator}} has been moved to {{.NewFlightNumber}} departing {{.NewDepartureTime}}.\nSeats: {{.Seats}}\n",
+		},
+		"id": {
+			Subject: "Booking {{.RecordLocator}} dipindah ke {{.NewFlightNumber}}",
+			Body:    "Halo {{.Name}},\n\nFlight {{.FlightNumber}} dibatalkan. Booking {{.RecordLocator}} sudah dipindah ke {{.NewFlightNumber}} berangkat {{.NewDepartureTime}}.\nKursi: {{.Seats}}\n",
+		},
+	},
+	EventFlightStatusChanged: {
+		"en": {
+			Subject: "{{.FlightNumber}} is {{.Status}}",
+			Body:    "Hi {{.Name}},\n\nFlight {{.FlightNumber}} ({{.From}} → {{.To}}) for booking {{.RecordLocator}} is now {{.Status}}.\n{{if .EstimatedDepartureTime}}Estimated departure: {{.EstimatedDepartureTime}}\n{{end}}{{if .Reason}}{{.Reason}}\n{{end}}",
+		},
+		"id": {
+			Subject: "{{.FlightNumber}} {{.Status}}",
+			Body:    "Halo {{.Name}},\n\nStatus flight {{.FlightNumber}} ({{.From}} → {{.To}}) untuk booking {{.RecordLocator}} sekarang {{.Status}}.\n{{if .EstimatedDepartureTime}}Perkiraan berangkat: {{.EstimatedDepartureTime}}\n{{end}}{{if .Reason}}{{.Reason}}\n{{end}}",
+		},
+	},
+	EventFlightRescheduled: {
+		"en": {
+			Subject: "{{.FlightNumber}} schedule changed",
+			Body: "Hi {{.Name}},\n\nThe schedule of flight {{.FlightNumber}} for booking {{.RecordLocator}} has changed.\n" +
+				"Now: {{.From}} → {{.To}}, departing {{.DepartureTime}}, arriving {{.ArrivalTime}}\n" +
+				"Previously: {{.PreviousFrom}} → {{.PreviousTo}}, departing {{.PreviousDepartureTime}}, arriving {{.PreviousArrivalTime}}\n",
+		},
+		"id": {
+			Subject: "Jadwal {{.FlightNumber}} berubah",
+			Body: "Halo {{.Name}},\n\nJadwal flight {{.FlightNumber}} untuk booking {{.RecordLocator}} berubah.\n" +
+				"Sekarang: {{.From}} → {{.To}}, berangkat {{.DepartureTime}}, tiba {{.ArrivalTime}}\n" +
+				"Sebelumnya: {{.PreviousFrom}} → {{.PreviousTo}}, berangkat {{.PreviousDepartureTime}}, tiba {{.PreviousArrivalTime}}\n",
+		},
+	},
+	EventFlightReseated: {
+		"en": {
+			Subject: "{{.FlightNumber}} aircraft changed",
+			Body:    "Hi {{.Name}},\n\nThe aircraft for flight {{.FlightNumber}} ({{.From}} → {{.To}}) departing {{.DepartureTime}} has changed.\nYour seats for booking {{.RecordLocator}}: {{if .Seats}}{{.Seats}}{{else}}to be assigned at check-in{{end}}\n",
+		},
+		"id": {
+			Subject: "Pesawat {{.FlightNumber}} diganti",
+			Body:    "Halo {{.Name}},\n\nPesawat untuk flight {{.FlightNumber}} ({{.From}} → {{.To}}) berangkat {{.DepartureTime}} diganti.\nKursi kamu untuk booking {{.RecordLocator}}: {{if .Seats}}{{.Seats}}{{else}}ditentukan saat check-in{{end}}\n",
+		},
+	},
+}
+
+// Templates → template per event per locale
+type Templates struct {
+	DefaultLocale string
+	templates     map[string]map[string]*parsedTemplate
+}
+
+type parsedTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+// LoadTemplates → template bawaan + override dari dir (file "<event>.<locale>.tmpl",
+// baris pertama "Subject: ...", sisanya body). dir kosong = bawaan saja.
+func LoadTemplates(dir, defaultLocale string) (*Templates, error) {
+	raw := map[string]map[string]Template{}
+	for event, locales := range defaultTemplates {
+		raw[event] = map[string]Template{}
+		for locale, tmpl := range locales {
+			raw[event][locale] = tmpl
+		}
+	}
+
+	if dir != "" {
+		files, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			name := strings.TrimSuffix(filepath.Base(file), ".tmpl")
+			dot := strings.LastIndex(name, ".")
+			if dot <= 0 {
+				return nil, fmt.Errorf("template %s: name must be <event>.<locale>.tmpl", file)
+			}
+			event, locale := name[:dot], name[dot+1:]
+
+			content, err := os.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			subject, body, _ := strings.Cut(string(content), "\n")
+			if !strings.HasPrefix(subject, "Subject:") {
+				return nil, fmt.Errorf("template %s: first line must be \"Subject: ...\"", file)
+			}
+			if raw[event] == nil {
+				raw[event] = map[string]Template{}
+			}
+			raw[event][locale] = Template{
+				Subject: strings.TrimSpace(strings.TrimPrefix(subject, "Subject:")),
+				Body:    strings.TrimLeft(body, "\r\n"),
+			}
+		}
+	}
+
+	t := &Templates{DefaultLocale: defaultLocale, templates: map[string]map[string]*parsedTemplate{}}
+	for event, locales := range raw {
+		t.templates[event] = map[string]*parsedTemplate{}
+		for locale, tmpl := range locales {
+			name := event + "." + locale
+			subject, err := template.New(name + ".subject").Option("missingkey=zero").Parse(tmpl.Subject)
+			if err != nil {
+				return nil, fmt.Errorf("template %s: %v", name, err)
+			}
+			body, err := template.New(name + ".body").Option("missingkey=zero").Parse(tmpl.Body)
+			if err != nil {
+				return nil, fmt.Errorf("template %s: %v", name, err)
+			}
+			t.templates[event][locale] = &parsedTemplate{subject: subject, body: body}
+		}
+	}
+	return t, nil
+}
+
+// Render → subject + body untuk event di locale tertentu, fallback ke DefaultLocale lalu "en"
+func (t *Templates) Render(event, locale string, data map[string]interface{}) (string, string, error) {
+	locales, ok := t.templates[event]
+	if !ok {
+		return "", "", fmt.Errorf("no template for event %s", event)
+	}
+	tmpl := locales[locale]
+	if tmpl == nil {
+		tmpl = locales[t.DefaultLocale]
+	}
+	if tmpl == nil {
+		tmpl = locales["en"]
+	}
+	if tmpl == nil {
+		return "", "", fmt.Errorf("no template for event %s in locale %s", event, locale)
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.subject.Execute(&subject, data); err != nil {
+		return "", "", err
+	}
+	if err := tmpl.body.Execute(&body, data); err != nil {
+		return "", "", err
+	}
+	return subject.String(), body.String(), nil
+}
@@ -0,0 +1,142 @@
+package notifications
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRenderDefaultTemplates(t *testing.T) {
+	templates, err := LoadTemplates("", "en")
+	if err != nil {
+		t.Fatalf("LoadTemplates() error = %v", err)
+	}
+
+	base := map[string]interface{}{
+		"Name":          "Budi",
+		"RecordLocator": "ABC123",
+		"FlightNumber":  "GA410",
+		"Currency":      "IDR",
+	}
+	with := func(extra map[string]interface{}) map[string]interface{} {
+		data := map[string]interface{}{}
+		for k, v := range base {
+			data[k] = v
+		}
+		for k, v := range extra {
+			data[k] = v
+		}
+		return data
+	}
+
+	tests := []struct {
+		name        string
+		event       string
+		locale      string
+		data        map[string]interface{}
+		wantSubject string
+		contains    []string
+		excludes    []string
+	}{
+		{
+			name:        "self-service cancellation without reason",
+			event:       EventBookingCancelled,
+			locale:      "en",
+			data:        base,
+			wantSubject: "Booking ABC123 cancelled",
+			contains:    []string{"has been cancelled.\n"},
+			excludes:    []string{"<no value>", "Refund"},
+		},
+		{
+			name:        "cancellation with reason and refund",
+			event:       EventBookingCancelled,
+			locale:      "id",
+			data:        with(map[string]interface{}{"Reason": "Flight dibatalkan maskapai", "Refunded": "500000.00"}),
+			wantSubject: "Booking ABC123 dibatalkan",
+			contains:    []string{"sudah dibatalkan. Flight dibatalkan maskapai\n", "Refund: IDR 500000.00"},
+		},
+		{
+			name:        "unknown locale falls back to default",
+			event:       EventBookingCancelled,
+			locale:      "fr",
+			data:        with(map[string]interface{}{"Reason": ""}),
+			wantSubject: "Booking ABC123 cancelled",
+			excludes:    []string{"<no value>"},
+		},
+		{
+			name:   "reschedule lists old and new times",
+			event:  EventFlightRescheduled,
+			locale: "en",
+			data: with(map[string]interface{}{
+				"From": "CGK", "To": "DPS", "DepartureTime": "2026-05-01 09:00 WIB", "ArrivalTime": "2026-05-01 12:00 WITA",
+				"PreviousFrom": "CGK", "PreviousTo": "DPS", "PreviousDepartureTime": "2026-05-01 08:00 WIB", "PreviousArrivalTime": "2026-05-01 11:00 WITA",
+			}),
+			wantSubject: "GA410 schedule changed",
+			contains:    []string{"departing 2026-05-01 09:00 WIB", "Previously: CGK → DPS, departing 2026-05-01 08:00 WIB"},
+		},
+		{
+			name:        "reseat without seats",
+			event:       EventFlightReseated,
+			locale:      "en",
+			data:        with(map[string]interface{}{"Seats": ""}),
+			wantSubject: "GA410 aircraft changed",
+			contains:    []string{"to be assigned at check-in"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subject, body, err := templates.Render(tt.event, tt.locale, tt.data)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if subject != tt.wantSubject {
+				t.Errorf("subject = %q, want %q", subject, tt.wantSubject)
+			}
+			for _, s := range tt.contains {
+				if !strings.Contains(body, s) {
+					t.Errorf("body %q does not contain %q", body, s)
+				}
+			}
+			for _, s := range tt.excludes {
+				if strings.Contains(body, s) {
+					t.Errorf("body %q contains %q", body, s)
+				}
+			}
+		})
+	}
+
+	if _, _, err := templates.Render("booking.unknown", "en", base); err == nil {
+		t.Error("Render() unknown event error = nil, want error")
+	}
+}
+
+func TestLoadTemplatesOverride(t *testing.T) {
+	dir := t.TempDir()
+	content := "Subject: Dibatalkan {{.RecordLocator}}\nBooking {{.RecordLocator}} batal.\n"
+	if err := os.WriteFile(filepath.Join(dir, EventBookingCancelled+".id.tmpl"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	templates, err := LoadTemplates(dir, "id")
+	if err != nil {
+		t.Fatalf("LoadTemplates() error = %v", err)
+	}
+	subject, body, err := templates.Render(EventBookingCancelled, "", map[string]interface{}{"RecordLocator": "ABC123"})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if subject != "Dibatalkan ABC123" || body != "Booking ABC123 batal.\n" {
+		t.Errorf("Render() = %q / %q, want override", subject, body)
+	}
+
+	// file tanpa baris Subject ditolak
+	bad := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bad, EventBookingCancelled+".en.tmpl"), []byte("no subject"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadTemplates(bad, "en"); err == nil {
+		t.Error("LoadTemplates() error = nil, want error for missing Subject line")
+	}
+}
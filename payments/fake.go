@@ -0,0 +1,120 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// FakeProvider → provider in-process buat development/testing.
+// Semua ID deterministik (hash dari input), token yang diawali "tok_decline" selalu ditolak.
+type FakeProvider struct {
+	webhookSecret []byte
+
+	mu             sync.Mutex
+	authorizations map[string]Authorization
+	refundCount    map[string]int
+}
+
+func NewFakeProvider(webhookSecret string) *FakeProvider {
+	return &FakeProvider{
+		webhookSecret:  []byte(webhookSecret),
+		authorizations: map[string]Authorization{},
+		refundCount:    map[string]int{},
+	}
+}
+
+func (p *FakeProvider) Name() string {
+	return "fake"
+}
+
+func (p *FakeProvider) Authorize(ctx context.Context, req AuthorizeRequest) (Authorization, error) {
+	if req.Amount <= 0 {
+		return Authorization{}, ErrInvalidAmount
+	}
+	if req.PaymentToken == "" || strings.HasPrefix(req.PaymentToken, "tok_decline") {
+		return Authorization{}, ErrDeclined
+	}
+
+	auth := Authorization{
+		ID:       "fake_auth_" + digest(req.Reference, fmt.Sprintf("%.2f", req.Amount), req.Currency, req.PaymentToken),
+		Amount:   req.Amount,
+		Currency: req.Currency,
+	}
+
+	p.mu.Lock()
+	p.authorizations[auth.ID] = auth
+	p.mu.Unlock()
+
+	return auth, nil
+}
+
+func (p *FakeProvider) Capture(ctx context.Context, authorizationID string, amount float64) (Capture, error) {
+	p.mu.Lock()
+	auth, ok := p.authorizations[authorizationID]
+	p.mu.Unlock()
+
+	if !ok {
+		return Capture{}, ErrUnknownPayment
+	}
+	if amount <= 0 || amount > auth.Amount {
+		return Capture{}, ErrInvalidAmount
+	}
+
+	return Capture{
+		ID:              "fake_cap_" + strings.TrimPrefix(authorizationID, "fake_auth_"),
+		AuthorizationID: authorizationID,
+		Amount:          amount,
+	}, nil
+}
+
+// Refund gak butuh state capture, biar refund tetap jalan setelah server restart
+func (p *FakeProvider) Refund(ctx context.Context, captureID string, amount float64) (Refund, error) {
+	if !strings.HasPrefix(captureID, "fake_cap_") {
+		return Refund{}, ErrUnknownPayment
+	}
+	if amount <= 0 {
+		return Refund{}, ErrInvalidAmount
+	}
+
+	p.mu.Lock()
+	p.refundCount[captureID]++
+	n := p.refundCount[captureID]
+	p.mu.Unlock()
+
+	return Refund{
+		ID:        "fake_ref_" + digest(captureID, fmt.Sprint(n), fmt.Sprintf("%.2f", amount)),
+		CaptureID: captureID,
+		Amount:    amount,
+	}, nil
+}
+
+func (p *FakeProvider) VerifyWebhook(payload []byte, signature string) (WebhookEvent, error) {
+	expected := p.SignWebhook(payload)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return WebhookEvent{}, ErrInvalidSignature
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return WebhookEvent{}, err
+	}
+	return event, nil
+}
+
+// SignWebhook → HMAC-SHA256 (hex) dari payload, dipakai buat simulasi webhook
+func (p *FakeProvider) SignWebhook(payload []byte) string {
+	mac := hmac.New(sha256.New, p.webhookSecret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func digest(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "|")))
+	return hex.EncodeToString(sum[:])[:16]
+}
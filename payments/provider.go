@@ -0,0 +1,56 @@
+package payments
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrDeclined         = errors.New("payment declined")
+	ErrUnknownPayment   = errors.New("unknown payment")
+	ErrInvalidAmount    = errors.New("invalid amount")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+)
+
+// Provider → abstraksi payment gateway (fake lokal, nanti bisa Midtrans/Stripe/dll)
+type Provider interface {
+	Name() string
+	Authorize(ctx context.Context, req AuthorizeRequest) (Authorization, error)
+	Capture(ctx context.Context, authorizationID string, amount float64) (Capture, error)
+	Refund(ctx context.Context, captureID string, amount float64) (Refund, error)
+	VerifyWebhook(payload []byte, signature string) (WebhookEvent, error)
+}
+
+type AuthorizeRequest struct {
+	Reference    string // biasanya booking id
+	Amount       float64
+	Currency     string
+	PaymentToken string // token kartu / metode bayar dari client
+}
+
+type Authorization struct {
+	ID       string
+	Amount   float64
+	Currency string
+}
+
+type Capture struct {
+	ID              string
+	AuthorizationID string
+	Amount          float64
+}
+
+type Refund struct {
+	ID        string
+	CaptureID string
+	Amount    float64
+}
+
+// WebhookEvent → payload notifikasi async dari provider
+type WebhookEvent struct {
+	ID              string  `json:"id"`
+	Type            string  `json:"type"` // payment.captured, payment.refunded, payment.failed
+	AuthorizationID string  `json:"authorizationId"`
+	CaptureID       string  `json:"captureId"`
+	Amount          float64 `json:"amount"`
+}
@@ -0,0 +1,28 @@
+package router
+
+import (
+	"airplane_booking_go/config"
+	"airplane_booking_go/controllers"
+	"airplane_booking_go/middlewares"
+	"airplane_booking_go/services"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func AircraftRoutes(r *gin.Engine, client *mongo.Client, db string) {
+	aircraftCollection := config.GetCollection(client, db, "aircraft")
+	aircraftController := controllers.NewAircraftController(aircraftCollection)
+
+	// aircraft dicari pakai type code, bukan _id
+	target := services.AuditTarget{Entity: "aircraft", Collection: aircraftCollection, Param: "code", Field: "typeCode"}
+
+	aircraft := r.Group("/aircraft", middlewares.AuthMiddleware(), middlewares.AdminOnly(), middlewares.Audit(newAuditLogger(client, db), target))
+	{
+		aircraft.POST("", aircraftController.CreateAircraft)
+		aircraft.GET("", aircraftController.GetAllAircraft)
+		aircraft.GET("/:code", aircraftController.GetAircraft)
+		aircraft.PUT("/:code", aircraftController.UpdateAircraft)
+		aircraft.DELETE("/:code", aircraftController.DeleteAircraft)
+	}
+}
@@ -0,0 +1,30 @@
+package router
+
+import (
+	"airplane_booking_go/config"
+	"airplane_booking_go/controllers"
+	"airplane_booking_go/middlewares"
+	"airplane_booking_go/services"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func AirlineRoutes(r *gin.Engine, client *mongo.Client, db string) {
+	airlineCollection := config.GetCollection(client, db, "airlines")
+	airlineController := controllers.NewAirlineController(airlineCollection)
+
+	// airline dicari pakai IATA code
+	target := services.AuditTarget{Entity: "airline", Collection: airlineCollection, Param: "code", Field: "iata"}
+	audit := middlewares.Audit(newAuditLogger(client, db), target)
+
+	r.GET("/airlines", airlineController.GetAllAirlines)
+	r.GET("/airlines/:code", airlineController.GetAirline)
+
+	airlines := r.Group("/airlines", middlewares.AuthMiddleware(), middlewares.AdminOnly(), audit)
+	{
+		airlines.POST("", airlineController.CreateAirline)
+		airlines.PUT("/:code", airlineController.UpdateAirline)
+		airlines.DELETE("/:code", airlineController.DeleteAirline)
+	}
+}
@@ -0,0 +1,31 @@
+package router
+
+import (
+	"airplane_booking_go/config"
+	"airplane_booking_go/controllers"
+	"airplane_booking_go/middlewares"
+	"airplane_booking_go/services"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func AirportRoutes(r *gin.Engine, client *mongo.Client, db string) {
+	airportCollection := config.GetCollection(client, db, "airports")
+	airportController := controllers.NewAirportController(airportCollection)
+
+	// airport dicari pakai IATA code
+	target := services.AuditTarget{Entity: "airport", Collection: airportCollection, Param: "code", Field: "code"}
+	audit := middlewares.Audit(newAuditLogger(client, db), target)
+
+	r.GET("/airports", airportController.GetAllAirports)
+	r.GET("/airports/:code", airportController.GetAirport)
+
+	airports := r.Group("/airports", middlewares.AuthMiddleware(), middlewares.AdminOnly(), audit)
+	{
+		airports.POST("", airportController.CreateAirport)
+		airports.POST("/import", airportController.ImportAirports)
+		airports.PUT("/:code", airportController.UpdateAirport)
+		airports.DELETE("/:code", airportController.DeleteAirport)
+	}
+}
@@ -0,0 +1,22 @@
+package router
+
+import (
+	"airplane_booking_go/config"
+	"airplane_booking_go/controllers"
+	"airplane_booking_go/middlewares"
+	"airplane_booking_go/services"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func AuditRoutes(r *gin.Engine, client *mongo.Client, db string) {
+	auditController := controllers.NewAuditController(newAuditLogger(client, db).Collection)
+
+	r.GET("/audit", middlewares.AuthMiddleware(), middlewares.AdminOnly(), auditController.GetAuditLogs)
+}
+
+// newAuditLogger → logger audit yang dipakai route lain (collection audit_logs)
+func newAuditLogger(client *mongo.Client, db string) *services.AuditLogger {
+	return services.NewAuditLogger(config.GetCollection(client, db, "audit_logs"))
+}
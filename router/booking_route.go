@@ -1,25 +1,52 @@
 package router
 
 import (
+	"log"
+
 	"airplane_booking_go/config"
 	"airplane_booking_go/controllers"
 	"airplane_booking_go/middlewares"
+	"airplane_booking_go/services"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func BookRoutes(r *gin.Engine, client *mongo.Client, db string) {
+func BookRoutes(r *gin.Engine, client *mongo.Client, db string, paymentService *services.PaymentService, waitlist *services.Waitlist) {
 	bookingCollection := config.GetCollection(client, db, "booking")
 	flightCollection := config.GetCollection(client, db, "flights")
-	bookingController := controllers.NewBookingController(bookingCollection, flightCollection)
+	airportCollection := config.GetCollection(client, db, "airports")
+	idempotencyCollection := config.GetCollection(client, db, "idempotency_keys")
+	cancellationPolicies, err := services.LoadCancellationPolicies(config.CancellationPolicyFile())
+	if err != nil {
+		log.Fatal("Error load cancellation policy:", err)
+	}
+	bookingController := controllers.NewBookingController(bookingCollection, flightCollection, airportCollection, paymentService, cancellationPolicies, waitlist)
+	waitlistController := controllers.NewWaitlistController(waitlist, bookingCollection)
+	auditLogger := newAuditLogger(client, db)
+	bookingAudit := middlewares.Audit(auditLogger, services.NewAuditTarget("booking", bookingCollection))
+	waitlistAudit := middlewares.Audit(auditLogger, services.NewAuditTarget("waitlist", waitlist.WaitlistCollection))
+
+	// lookup pakai record locator + nama belakang, tanpa login
+	r.GET("/booking/lookup", bookingController.LookupBooking)
 
 	booking := r.Group("/booking", middlewares.AuthMiddleware())
 	{
-    	booking.POST("/book", middlewares.AuthMiddleware(), bookingController.CreateBooking)
+    	booking.POST("/book", middlewares.AuthMiddleware(), middlewares.Idempotency(idempotencyCollection), bookingAudit, bookingController.CreateBooking)
+    	booking.POST("/itinerary", middlewares.Idempotency(idempotencyCollection), bookingAudit, bookingController.CreateItineraryBooking)
     	booking.GET("/book", bookingController.GetAllBookings)
     	booking.GET("/user/book", middlewares.AuthMiddleware(), bookingController.GetUserBookings)
     	booking.GET("/book/:id", middlewares.AuthMiddleware(), bookingController.GetUserBookingDetail)
-    	booking.PUT("/book/:id/cancel", middlewares.AuthMiddleware(), bookingController.CancelBooking)
+    	booking.POST("/book/:id/pay", middlewares.Idempotency(idempotencyCollection), bookingAudit, bookingController.PayBooking)
+    	booking.GET("/book/:id/cancel/preview", bookingController.PreviewCancellation)
+    	booking.PUT("/book/:id/cancel", middlewares.AuthMiddleware(), bookingAudit, bookingController.CancelBooking)
+    	booking.PUT("/book/:id/status", bookingAudit, bookingController.UpdateBookingStatus)
+    	booking.PUT("/book/:id/seats", bookingAudit, bookingController.ChangeSeats)
+    	booking.POST("/book/:id/change-flight", middlewares.Idempotency(idempotencyCollection), bookingAudit, bookingController.ChangeFlight)
+
+    	booking.POST("/waitlist", waitlistAudit, waitlistController.JoinWaitlist)
+    	booking.GET("/waitlist", waitlistController.GetUserWaitlist)
+    	booking.POST("/waitlist/:id/claim", middlewares.Idempotency(idempotencyCollection), waitlistAudit, waitlistController.ClaimWaitlistOffer)
+    	booking.DELETE("/waitlist/:id", waitlistAudit, waitlistController.LeaveWaitlist)
 	}
 }
@@ -3,17 +3,29 @@ package router
 import (
 	"airplane_booking_go/config"
 	"airplane_booking_go/controllers"
+	"airplane_booking_go/middlewares"
+	"airplane_booking_go/services"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func FlightRoutes(r *gin.Engine, client *mongo.Client, db string) {
+func FlightRoutes(r *gin.Engine, client *mongo.Client, db string, paymentService *services.PaymentService, waitlist *services.Waitlist) {
 	flightCollection := config.GetCollection(client, db, "flights")
-	flightController := controllers.NewFlightController(flightCollection)
+	aircraftCollection := config.GetCollection(client, db, "aircraft")
+	bookingCollection := config.GetCollection(client, db, "booking")
+	airportCollection := config.GetCollection(client, db, "airports")
+	airlineCollection := config.GetCollection(client, db, "airlines")
+	reaccommodator := services.NewReaccommodator(bookingCollection, flightCollection, paymentService, waitlist, config.ReaccommodationWindow())
+	flightController := controllers.NewFlightController(flightCollection, aircraftCollection, airportCollection, airlineCollection, bookingCollection, waitlist, reaccommodator)
+	audit := middlewares.Audit(newAuditLogger(client, db), services.NewAuditTarget("flight", flightCollection))
 
-	r.POST("/flights", flightController.CreateFlight)
+	r.POST("/flights", middlewares.AuthMiddleware(), middlewares.AdminOnly(), audit, flightController.CreateFlight)
 	r.GET("/flights", flightController.GetAllFlights)
+	r.GET("/flights/itineraries", flightController.SearchItineraries)
 	r.GET("/flights/:id", flightController.GetFlightByID)
-	r.PUT("/flights/:id", flightController.UpdateFlight)
+	r.PUT("/flights/:id", middlewares.AuthMiddleware(), middlewares.AdminOnly(), audit, flightController.UpdateFlight)
+	r.PUT("/flights/:id/status", middlewares.AuthMiddleware(), middlewares.AdminOnly(), audit, flightController.UpdateFlightStatus)
+	r.POST("/flights/:id/cancel", middlewares.AuthMiddleware(), middlewares.AdminOnly(), audit, flightController.CancelFlight)
+	r.PUT("/flights/:id/aircraft", middlewares.AuthMiddleware(), middlewares.AdminOnly(), audit, flightController.ChangeAircraft)
 }
@@ -0,0 +1,18 @@
+package router
+
+import (
+	"airplane_booking_go/config"
+	"airplane_booking_go/controllers"
+	"airplane_booking_go/services"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func PaymentRoutes(r *gin.Engine, client *mongo.Client, db string, paymentService *services.PaymentService) {
+	bookingCollection := config.GetCollection(client, db, "booking")
+	paymentController := controllers.NewPaymentController(paymentService, bookingCollection)
+
+	// webhook gak pakai JWT, diverifikasi lewat signature
+	r.POST("/payments/webhook", paymentController.HandleWebhook)
+}
@@ -0,0 +1,31 @@
+package router
+
+import (
+	"airplane_booking_go/config"
+	"airplane_booking_go/controllers"
+	"airplane_booking_go/middlewares"
+	"airplane_booking_go/services"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func ScheduleRoutes(r *gin.Engine, client *mongo.Client, db string, generator *services.ScheduleGenerator) {
+	scheduleCollection := config.GetCollection(client, db, "schedules")
+	aircraftCollection := config.GetCollection(client, db, "aircraft")
+	airportCollection := config.GetCollection(client, db, "airports")
+	airlineCollection := config.GetCollection(client, db, "airlines")
+	scheduleController := controllers.NewScheduleController(scheduleCollection, aircraftCollection, airportCollection, airlineCollection, generator)
+
+	audit := middlewares.Audit(newAuditLogger(client, db), services.NewAuditTarget("schedule", scheduleCollection))
+
+	schedules := r.Group("/schedules", middlewares.AuthMiddleware(), middlewares.AdminOnly(), audit)
+	{
+		schedules.POST("", scheduleController.CreateSchedule)
+		schedules.GET("", scheduleController.GetAllSchedules)
+		schedules.GET("/:id", scheduleController.GetSchedule)
+		schedules.PUT("/:id", scheduleController.UpdateSchedule)
+		schedules.DELETE("/:id", scheduleController.DeleteSchedule)
+		schedules.POST("/:id/generate", scheduleController.GenerateFlights)
+	}
+}
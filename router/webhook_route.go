@@ -0,0 +1,29 @@
+package router
+
+import (
+	"airplane_booking_go/controllers"
+	"airplane_booking_go/middlewares"
+	"airplane_booking_go/services"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func WebhookRoutes(r *gin.Engine, client *mongo.Client, db string, dispatcher *services.WebhookDispatcher) {
+	webhookController := controllers.NewWebhookController(dispatcher)
+	auditLogger := newAuditLogger(client, db)
+	subscriptionAudit := middlewares.Audit(auditLogger, services.NewAuditTarget("webhook_subscription", dispatcher.SubscriptionCollection))
+	deliveryAudit := middlewares.Audit(auditLogger, services.NewAuditTarget("webhook_delivery", dispatcher.DeliveryCollection))
+
+	webhooks := r.Group("/webhooks", middlewares.AuthMiddleware(), middlewares.AdminOnly())
+	{
+		webhooks.POST("", subscriptionAudit, webhookController.CreateSubscription)
+		webhooks.GET("", webhookController.GetAllSubscriptions)
+		webhooks.GET("/deliveries", webhookController.GetDeliveries)
+		webhooks.GET("/deliveries/dead", webhookController.GetDeadLetters)
+		webhooks.POST("/deliveries/:id/retry", deliveryAudit, webhookController.RetryDelivery)
+		webhooks.GET("/:id", webhookController.GetSubscription)
+		webhooks.PUT("/:id", subscriptionAudit, webhookController.UpdateSubscription)
+		webhooks.DELETE("/:id", subscriptionAudit, webhookController.DeleteSubscription)
+	}
+}
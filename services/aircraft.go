@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"airplane_booking_go/models"
+)
+
+var ErrUnknownAircraft = errors.New("unknown aircraft type")
+
+// AircraftLayout → ambil template kabin aircraft lalu isi fare per class, siap di-GenerateSeats
+func AircraftLayout(ctx context.Context, aircraftColl *mongo.Collection, typeCode string, fares map[string]float64) (models.Aircraft, models.CabinLayout, error) {
+	var aircraft models.Aircraft
+	err := aircraftColl.FindOne(ctx, bson.M{"typeCode": strings.ToUpper(typeCode)}).Decode(&aircraft)
+	if err == mongo.ErrNoDocuments {
+		return aircraft, models.CabinLayout{}, fmt.Errorf("%w: %s", ErrUnknownAircraft, typeCode)
+	}
+	if err != nil {
+		return aircraft, models.CabinLayout{}, err
+	}
+
+	layout, err := ApplyFares(aircraft.Layout, fares)
+	if err != nil {
+		return aircraft, models.CabinLayout{}, err
+	}
+	return aircraft, layout, nil
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"airplane_booking_go/models"
+)
+
+var (
+	ErrUnknownAirline      = errors.New("unknown airline")
+	ErrInvalidFlightNumber = errors.New("invalid flight number")
+)
+
+// bagian angka nomor penerbangan setelah prefix maskapai: 1-4 digit + suffix huruf opsional, ex: "410", "7510A"
+var flightNumberPattern = regexp.MustCompile(`^\d{1,4}[A-Z]?$`)
+
+// ResolveAirline → maskapai by IATA (2 huruf) atau ICAO (3 huruf) code
+func ResolveAirline(ctx context.Context, airlineColl *mongo.Collection, code string) (models.Airline, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	filter := bson.M{"iata": code}
+	if len(code) == 3 {
+		filter = bson.M{"icao": code}
+	}
+
+	var airline models.Airline
+	err := airlineColl.FindOne(ctx, filter).Decode(&airline)
+	if err == mongo.ErrNoDocuments {
+		return models.Airline{}, fmt.Errorf("%w: %s", ErrUnknownAirline, code)
+	}
+	return airline, err
+}
+
+// NormalizeFlightNumber → cek prefix nomor penerbangan sesuai maskapai, hasilnya format IATA tanpa spasi ("GA410").
+// Prefix ICAO ("GIA410") juga diterima.
+func NormalizeFlightNumber(airline models.Airline, flightNumber string) (string, error) {
+	flightNumber = strings.ToUpper(strings.TrimSpace(flightNumber))
+	for _, prefix := range []string{airline.IATA, airline.ICAO} {
+		if prefix == "" || !strings.HasPrefix(flightNumber, prefix) {
+			continue
+		}
+		number := strings.TrimSpace(strings.TrimPrefix(flightNumber, prefix))
+		if flightNumberPattern.MatchString(number) {
+			return airline.IATA + number, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q must start with carrier code %s followed by 1-4 digits", ErrInvalidFlightNumber, flightNumber, airline.IATA)
+}
+
+// ResolveCarrier → maskapai dari code + nomor penerbangan yang sudah dinormalisasi
+func ResolveCarrier(ctx context.Context, airlineColl *mongo.Collection, code, flightNumber string) (models.Airline, string, error) {
+	airline, err := ResolveAirline(ctx, airlineColl, code)
+	if err != nil {
+		return models.Airline{}, "", err
+	}
+	number, err := NormalizeFlightNumber(airline, flightNumber)
+	if err != nil {
+		return models.Airline{}, "", err
+	}
+	return airline, number, nil
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"airplane_booking_go/models"
+)
+
+func TestNormalizeFlightNumber(t *testing.T) {
+	garuda := models.Airline{IATA: "GA", ICAO: "GIA", Name: "Garuda Indonesia"}
+	noICAO := models.Airline{IATA: "3K", Name: "Jetstar Asia"}
+
+	tests := []struct {
+		airline models.Airline
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{garuda, "GA410", "GA410", false},
+		{garuda, " ga 410 ", "GA410", false},
+		{garuda, "GIA410", "GA410", false},
+		{garuda, "gia 7", "GA7", false},
+		{garuda, "GA7510A", "GA7510A", false},
+		{noICAO, "3K531", "3K531", false},
+		{garuda, "GA12345", "", true},
+		{garuda, "GA410AB", "", true},
+		{garuda, "GA", "", true},
+		{garuda, "GAA410", "", true},
+		{garuda, "QZ410", "", true},
+		{garuda, "410", "", true},
+		{noICAO, "JSA531", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.airline.IATA+"/"+tt.input, func(t *testing.T) {
+			got, err := NormalizeFlightNumber(tt.airline, tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidFlightNumber) {
+					t.Fatalf("NormalizeFlightNumber(%q) = %q, %v; want ErrInvalidFlightNumber", tt.input, got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeFlightNumber(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeFlightNumber(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"airplane_booking_go/models"
+)
+
+var (
+	ErrUnknownAirport = errors.New("unknown airport")
+	ErrSameAirport    = errors.New("departure and arrival must be different airports")
+)
+
+const (
+	airportImportBatch     = 500
+	airportImportMaxErrors = 50 // baris gagal yang dilaporkan, sisanya cuma dihitung
+)
+
+// ValidateAirport → timezone harus IANA yang dikenal, koordinat dalam batas
+func ValidateAirport(airport models.Airport) error {
+	if airport.TimeZone != "" {
+		if _, err := time.LoadLocation(airport.TimeZone); err != nil {
+			return fmt.Errorf("airport %s: unknown timeZone %q", airport.Code, airport.TimeZone)
+		}
+	}
+	if airport.Latitude < -90 || airport.Latitude > 90 {
+		return fmt.Errorf("airport %s: latitude must be between -90 and 90", airport.Code)
+	}
+	if airport.Longitude < -180 || airport.Longitude > 180 {
+		return fmt.Errorf("airport %s: longitude must be between -180 and 180", airport.Code)
+	}
+	return nil
+}
+
+// ResolveAirport → data bandara dari collection airports berdasarkan IATA code
+func ResolveAirport(ctx context.Context, airportColl *mongo.Collection, code string) (models.Airport, error) {
+	var record models.AirportRecord
+	err := airportColl.FindOne(ctx, bson.M{"code": strings.ToUpper(code)}).Decode(&record)
+	if err == mongo.ErrNoDocuments {
+		return models.Airport{}, fmt.Errorf("%w: %s", ErrUnknownAirport, code)
+	}
+	if err != nil {
+		return models.Airport{}, err
+	}
+	return record.Airport, nil
+}
+
+// ResolveRoute → bandara asal + tujuan sekaligus
+func ResolveRoute(ctx context.Context, airportColl *mongo.Collection, from, to string) (models.Airport, models.Airport, error) {
+	departure, err := ResolveAirport(ctx, airportColl, from)
+	if err != nil {
+		return models.Airport{}, models.Airport{}, err
+	}
+	arrival, err := ResolveAirport(ctx, airportColl, to)
+	if err != nil {
+		return models.Airport{}, models.Airport{}, err
+	}
+	if departure.Code == arrival.Code {
+		return models.Airport{}, models.Airport{}, ErrSameAirport
+	}
+	return departure, arrival, nil
+}
+
+// AirportImportResult → ringkasan import CSV
+type AirportImportResult struct {
+	Inserted int      `json:"inserted"`
+	Updated  int      `json:"updated"`
+	Skipped  int      `json:"skipped"` // tanpa IATA code / bandara sudah tutup
+	Failed   int      `json:"failed"`
+	Errors   []string `json:"errors"`
+}
+
+// ImportAirportsCSV → upsert bandara dari CSV format OurAirports (airports.csv).
+// Kolom yang dipakai: iata_code, icao_code / gps_code (ICAO, 4 karakter), type, name, municipality, iso_country,
+// latitude_deg, longitude_deg, plus kolom tambahan time_zone (IANA) karena OurAirports gak punya.
+// Baris tanpa iata_code atau type "closed" dilewati. Kalau time_zone kosong, timezone lama dipertahankan.
+func ImportAirportsCSV(ctx context.Context, airportColl *mongo.Collection, r io.Reader) (AirportImportResult, error) {
+	result := AirportImportResult{Errors: []string{}}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return result, fmt.Errorf("read csv header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["iata_code"]; !ok {
+		return result, errors.New("csv must have an iata_code column")
+	}
+	field := func(row []string, names ...string) string {
+		for _, name := range names {
+			if i, ok := columns[name]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+		}
+		return ""
+	}
+	fail := func(line int, err error) {
+		result.Failed++
+		if len(result.Errors) < airportImportMaxErrors {
+			result.Errors = append(result.Errors, fmt.Sprintf("line %d: %v", line, err))
+		}
+	}
+
+	var batch []mongo.WriteModel
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		res, err := airportColl.BulkWrite(ctx, batch, options.BulkWrite().SetOrdered(false))
+		if res != nil {
+			result.Inserted += int(res.UpsertedCount)
+			result.Updated += int(res.ModifiedCount)
+		}
+		batch = batch[:0]
+		return err
+	}
+
+	line := 1
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			fail(line, err)
+			continue
+		}
+
+		code := strings.ToUpper(field(row, "iata_code"))
+		if code == "" || field(row, "type") == "closed" {
+			result.Skipped++
+			continue
+		}
+		if len(code) != 3 {
+			fail(line, fmt.Errorf("invalid iata_code %q", code))
+			continue
+		}
+
+		airport := models.Airport{
+			Code:     code,
+			Name:     field(row, "name"),
+			City:     field(row, "municipality", "city"),
+			Country:  field(row, "iso_country", "country"),
+			TimeZone: field(row, "time_zone", "timezone", "tz"),
+		}
+		if airport.Latitude, err = parseCoordinate(field(row, "latitude_deg", "latitude")); err != nil {
+			fail(line, fmt.Errorf("invalid latitude: %w", err))
+			continue
+		}
+		if airport.Longitude, err = parseCoordinate(field(row, "longitude_deg", "longitude")); err != nil {
+			fail(line, fmt.Errorf("invalid longitude: %w", err))
+			continue
+		}
+		if airport.Name == "" {
+			fail(line, errors.New("name is required"))
+			continue
+		}
+		if err := ValidateAirport(airport); err != nil {
+			fail(line, err)
+			continue
+		}
+
+		now := time.Now()
+		set := bson.M{
+			"name":      airport.Name,
+			"city":      airport.City,
+			"country":   airport.Country,
+			"latitude":  airport.Latitude,
+			"longitude": airport.Longitude,
+			"type":      field(row, "type"),
+		}
+		// ident OurAirports sering bukan ICAO (ex: "00A"), jadi cuma icao_code / gps_code yang valid
+		if icao := strings.ToUpper(field(row, "icao_code", "gps_code")); isICAOCode(icao) {
+			set["icao"] = icao
+		}
+		if airport.TimeZone != "" {
+			set["timeZone"] = airport.TimeZone
+		}
+
+		// updatedAt cuma diganti kalau ada field yang berubah, biar ModifiedCount = baris yang benar-benar berubah
+		unchanged := bson.A{}
+		values := bson.M{}
+		for key, value := range set {
+			unchanged = append(unchanged, bson.M{"$eq": bson.A{"$" + key, bson.M{"$literal": value}}})
+			values[key] = bson.M{"$literal": value}
+		}
+		batch = append(batch, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"code": code}).
+			SetUpdate(mongo.Pipeline{
+				{{Key: "$set", Value: bson.M{
+					"updatedAt": bson.M{"$cond": bson.A{bson.M{"$and": unchanged}, "$updatedAt", now}},
+					"createdAt": bson.M{"$ifNull": bson.A{"$createdAt", now}},
+				}}},
+				{{Key: "$set", Value: values}},
+			}).
+			SetUpsert(true))
+
+		if len(batch) >= airportImportBatch {
+			if err := flush(); err != nil {
+				return result, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// isICAOCode → 4 huruf/angka, sama dengan validasi ICAO di admin CRUD
+func isICAOCode(code string) bool {
+	if len(code) != 4 {
+		return false
+	}
+	for _, r := range code {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func parseCoordinate(value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(value, 64)
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"airplane_booking_go/models"
+)
+
+// field yang gak ikut di-diff: noise (updatedAt) atau rahasia
+var (
+	auditIgnoredFields  = map[string]bool{"updatedAt": true}
+	auditRedactedFields = map[string]bool{"password": true, "secret": true}
+)
+
+// AuditLogger → tulis audit log ke collection append-only (cuma InsertOne, gak ada update/delete)
+type AuditLogger struct {
+	Collection *mongo.Collection
+}
+
+func NewAuditLogger(auditColl *mongo.Collection) *AuditLogger {
+	return &AuditLogger{Collection: auditColl}
+}
+
+// AuditTarget → cara menemukan dokumen yang diubah sebuah route, buat snapshot sebelum/sesudah
+type AuditTarget struct {
+	Entity     string
+	Collection *mongo.Collection
+	Param      string // nama route param berisi id, ex: "id", "code"
+	Field      string // field di dokumen, default "_id" (ObjectID)
+}
+
+// NewAuditTarget → target standar: param :id = _id dokumen
+func NewAuditTarget(entity string, coll *mongo.Collection) AuditTarget {
+	return AuditTarget{Entity: entity, Collection: coll, Param: "id", Field: "_id"}
+}
+
+// Snapshot → dokumen target saat ini, nil kalau id kosong / gak ketemu
+func (t AuditTarget) Snapshot(ctx context.Context, id string) bson.M {
+	if t.Collection == nil || id == "" {
+		return nil
+	}
+
+	var filter bson.M
+	if t.Field == "" || t.Field == "_id" {
+		objID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil
+		}
+		filter = bson.M{"_id": objID}
+	} else {
+		filter = bson.M{t.Field: strings.ToUpper(id)}
+	}
+
+	var doc bson.M
+	if err := t.Collection.FindOne(ctx, filter).Decode(&doc); err != nil {
+		return nil
+	}
+	return doc
+}
+
+// Record → simpan satu audit log
+func (a *AuditLogger) Record(ctx context.Context, entry models.AuditLog) error {
+	if entry.ID.IsZero() {
+		entry.ID = primitive.NewObjectID()
+	}
+	if entry.At.IsZero() {
+		entry.At = time.Now()
+	}
+	_, err := a.Collection.InsertOne(ctx, entry)
+	return err
+}
+
+// AuditDiff → field top-level yang beda antara before dan after (urut nama field)
+func AuditDiff(before, after bson.M) []models.AuditChange {
+	fields := map[string]bool{}
+	for k := range before {
+		fields[k] = true
+	}
+	for k := range after {
+		fields[k] = true
+	}
+	names := make([]string, 0, len(fields))
+	for k := range fields {
+		if !auditIgnoredFields[k] {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+
+	var changes []models.AuditChange
+	for _, field := range names {
+		b, a := before[field], after[field]
+		if reflect.DeepEqual(b, a) {
+			continue
+		}
+		if auditRedactedFields[field] {
+			b, a = redact(b), redact(a)
+		}
+		changes = append(changes, models.AuditChange{Field: field, Before: b, After: a})
+	}
+	return changes
+}
+
+func redact(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	return "[redacted]"
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"airplane_booking_go/models"
+)
+
+func TestAuditDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		before bson.M
+		after  bson.M
+		want   []models.AuditChange
+	}{
+		{
+			name:   "create has only after values",
+			before: nil,
+			after:  bson.M{"name": "Soekarno-Hatta", "code": "CGK"},
+			want: []models.AuditChange{
+				{Field: "code", After: "CGK"},
+				{Field: "name", After: "Soekarno-Hatta"},
+			},
+		},
+		{
+			name:   "delete has only before values",
+			before: bson.M{"code": "CGK"},
+			after:  nil,
+			want:   []models.AuditChange{{Field: "code", Before: "CGK"}},
+		},
+		{
+			name:   "unchanged fields and updatedAt are skipped",
+			before: bson.M{"code": "CGK", "city": "Jakarta", "updatedAt": 1},
+			after:  bson.M{"code": "CGK", "city": "Tangerang", "updatedAt": 2},
+			want:   []models.AuditChange{{Field: "city", Before: "Jakarta", After: "Tangerang"}},
+		},
+		{
+			name:   "nested values compared deeply",
+			before: bson.M{"seats": bson.A{bson.M{"number": "1A", "isAvailable": true}}},
+			after:  bson.M{"seats": bson.A{bson.M{"number": "1A", "isAvailable": false}}},
+			want: []models.AuditChange{{
+				Field:  "seats",
+				Before: bson.A{bson.M{"number": "1A", "isAvailable": true}},
+				After:  bson.A{bson.M{"number": "1A", "isAvailable": false}},
+			}},
+		},
+		{
+			name:   "secrets are redacted",
+			before: bson.M{"password": "old-hash", "secret": nil},
+			after:  bson.M{"password": "new-hash", "secret": "whsec"},
+			want: []models.AuditChange{
+				{Field: "password", Before: "[redacted]", After: "[redacted]"},
+				{Field: "secret", Before: nil, After: "[redacted]"},
+			},
+		},
+		{
+			name:   "identical documents",
+			before: bson.M{"code": "CGK"},
+			after:  bson.M{"code": "CGK"},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AuditDiff(tt.before, tt.after); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AuditDiff() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"airplane_booking_go/events"
+	"airplane_booking_go/models"
+)
+
+var (
+	ErrPaymentRequired = errors.New("paymentToken is required to pay the fare difference")
+	ErrPaymentFailed   = errors.New("payment failed")
+)
+
+// SeatChangePlan → hasil validasi ganti kursi sebelum dieksekusi
+type SeatChangePlan struct {
+	Swaps          []models.SeatSwap
+	NewSeats       []models.Seat // seat list booking setelah diganti
+	FareDifference float64
+}
+
+// PlanSeatChange → cek flight belum berangkat, kursi lama milik booking, kursi baru tersedia, dan hitung selisih harga
+func PlanSeatChange(booking models.Booking, flight models.Flight, swaps []models.SeatSwap) (SeatChangePlan, error) {
+	// EnsureBookable sekalian cek jam berangkat yang sudah lewat
+	if err := EnsureBookable(flight); err != nil {
+		return SeatChangePlan{}, err
+	}
+
+	owned := map[string]models.Seat{}
+	for _, seat := range booking.Seats {
+		owned[seat.Number] = seat
+	}
+	available := map[string]models.Seat{}
+	for _, seat := range flight.Seats {
+		available[seat.Number] = seat
+	}
+
+	replace := map[string]models.Seat{}
+	taken := map[string]bool{}
+	diff := 0.0
+	for _, swap := range swaps {
+		oldSeat, ok := owned[swap.From]
+		if !ok {
+			return SeatChangePlan{}, fmt.Errorf("seat %s is not part of this booking", swap.From)
+		}
+		if _, dup := replace[swap.From]; dup {
+			return SeatChangePlan{}, fmt.Errorf("seat %s changed more than once", swap.From)
+		}
+		newSeat, ok := available[swap.To]
+		if !ok {
+			return SeatChangePlan{}, fmt.Errorf("seat %s not found", swap.To)
+		}
+		if !newSeat.IsBookable() || taken[swap.To] {
+			return SeatChangePlan{}, fmt.Errorf("seat %s not available", swap.To)
+		}
+		taken[swap.To] = true
+		replace[swap.From] = newSeat
+		diff += newSeat.Price - oldSeat.Price
+	}
+
+	newSeats := make([]models.Seat, 0, len(booking.Seats))
+	for _, seat := range booking.Seats {
+		if next, ok := replace[seat.Number]; ok {
+			next.IsAvailable = false
+			newSeats = append(newSeats, next)
+		} else {
+			newSeats = append(newSeats, seat)
+		}
+	}
+
+	return SeatChangePlan{Swaps: swaps, NewSeats: newSeats, FareDifference: round2(diff)}, nil
+}
+
+// ApplySeatChange → claim kursi baru, lepas kursi lama, update booking. Semua dalam satu transaksi.
+func ApplySeatChange(ctx context.Context, bookingColl, flightColl *mongo.Collection, booking models.Booking, plan SeatChangePlan, change models.BookingChange) error {
+	session, err := bookingColl.Database().Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	from := make([]string, 0, len(plan.Swaps))
+	to := make([]string, 0, len(plan.Swaps))
+	seatMap := map[string]string{}
+	for _, swap := range plan.Swaps {
+		from = append(from, swap.From)
+		to = append(to, swap.To)
+		seatMap[swap.From] = swap.To
+	}
+
+	passengers := make([]models.Passenger, len(booking.Passengers))
+	copy(passengers, booking.Passengers)
+	for i := range passengers {
+		if next, ok := seatMap[passengers[i].SeatNumber]; ok {
+			passengers[i].SeatNumber = next
+		}
+	}
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		if err := ClaimSeats(sessCtx, flightColl, booking.FlightID, to); err != nil {
+			return nil, err
+		}
+
+		released := make([]models.Seat, 0, len(from))
+		for _, number := range from {
+			released = append(released, models.Seat{Number: number})
+		}
+		if err := ReleaseSeats(sessCtx, flightColl, booking.FlightID, released); err != nil {
+			return nil, err
+		}
+
+		// pastikan booking belum berubah sejak divalidasi
+		result, err := bookingColl.UpdateOne(sessCtx,
+			bson.M{
+				"_id":          booking.ID,
+				"status":       models.BookingStatusConfirmed,
+				"seats.number": bson.M{"$all": from},
+			},
+			bson.M{
+				"$set": bson.M{
+					"seats":      plan.NewSeats,
+					"passengers": passengers,
+					"totalPrice": round2(booking.TotalPrice + change.FareDifference),
+					"updatedAt":  time.Now(),
+				},
+				"$push": bson.M{"changes": change},
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		if result.ModifiedCount == 0 {
+			return nil, ErrBookingChanged
+		}
+		return nil, recordBookingChange(sessCtx, bookingColl.Database(), booking.ID, change)
+	})
+	return err
+}
+
+// FlightChangePlan → hasil validasi pindah flight sebelum dieksekusi
+type FlightChangePlan struct {
+	NewFlight      models.Flight
+	NewSeats       []models.Seat // urutannya sama dengan booking.Passengers
+	FareDifference float64
+}
+
+// PlanFlightChange → cek rute sama, kursi baru tersedia (satu per penumpang), dan hitung selisih harga
+func PlanFlightChange(booking models.Booking, oldFlight, newFlight models.Flight, seatNumbers []string) (FlightChangePlan, error) {
+	if newFlight.ID == oldFlight.ID {
+		return FlightChangePlan{}, errors.New("booking is already on this flight")
+	}
+	if newFlight.Departure.Code != oldFlight.Departure.Code || newFlight.Arrival.Code != oldFlight.Arrival.Code {
+		return FlightChangePlan{}, fmt.Errorf("flight %s does not fly %s → %s", newFlight.FlightNumber, oldFlight.Departure.Code, oldFlight.Arrival.Code)
+	}
+	if err := EnsureBookable(newFlight); err != nil {
+		return FlightChangePlan{}, err
+	}
+	if len(seatNumbers) != len(booking.Seats) {
+		return FlightChangePlan{}, fmt.Errorf("need exactly %d seats on the new flight", len(booking.Seats))
+	}
+
+	available := map[string]models.Seat{}
+	for _, seat := range newFlight.Seats {
+		available[seat.Number] = seat
+	}
+
+	newSeats := make([]models.Seat, 0, len(seatNumbers))
+	taken := map[string]bool{}
+	newTotal := 0.0
+	for _, number := range seatNumbers {
+		seat, ok := available[number]
+		if !ok {
+			return FlightChangePlan{}, fmt.Errorf("seat %s not found", number)
+		}
+		if !seat.IsBookable() || taken[number] {
+			return FlightChangePlan{}, fmt.Errorf("seat %s not available", number)
+		}
+		taken[number] = true
+		seat.IsAvailable = false
+		newSeats = append(newSeats, seat)
+		newTotal += seat.Price
+	}
+
+	oldTotal := 0.0
+	for _, seat := range booking.Seats {
+		oldTotal += seat.Price
+	}
+
+	return FlightChangePlan{NewFlight: newFlight, NewSeats: newSeats, FareDifference: round2(newTotal - oldTotal)}, nil
+}
+
+// ApplyFlightChange → lepas kursi di flight lama, claim kursi di flight baru, update booking. Satu transaksi.
+func ApplyFlightChange(ctx context.Context, bookingColl, flightColl *mongo.Collection, booking models.Booking, plan FlightChangePlan, change models.BookingChange) error {
+	session, err := bookingColl.Database().Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	seatNumbers := make([]string, 0, len(plan.NewSeats))
+	for _, seat := range plan.NewSeats {
+		seatNumbers = append(seatNumbers, seat.Number)
+	}
+
+	// penumpang ke-i dapat kursi baru ke-i
+	passengers := make([]models.Passenger, len(booking.Passengers))
+	copy(passengers, booking.Passengers)
+	for i := range passengers {
+		if i < len(seatNumbers) {
+			passengers[i].SeatNumber = seatNumbers[i]
+		}
+	}
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		if err := ReleaseSeats(sessCtx, flightColl, booking.FlightID, booking.Seats); err != nil {
+			return nil, err
+		}
+		if err := ClaimSeats(sessCtx, flightColl, plan.NewFlight.ID, seatNumbers); err != nil {
+			return nil, err
+		}
+
+		result, err := bookingColl.UpdateOne(sessCtx,
+			bson.M{"_id": booking.ID, "status": booking.Status, "flightId": booking.FlightID},
+			bson.M{
+				"$set": bson.M{
+					"flightId":   plan.NewFlight.ID,
+					"seats":      plan.NewSeats,
+					"passengers": passengers,
+					"totalPrice": round2(booking.TotalPrice + change.FareDifference),
+					"updatedAt":  time.Now(),
+				},
+				"$push": bson.M{"changes": change},
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		if result.ModifiedCount == 0 {
+			return nil, ErrBookingChanged
+		}
+		return nil, recordBookingChange(sessCtx, bookingColl.Database(), booking.ID, change)
+	})
+	return err
+}
+
+// Settlement → hasil penagihan/refund dari perubahan booking
+type Settlement struct {
+	Payment  *models.Payment `json:"payment,omitempty"`
+	Refunded float64         `json:"refunded"`
+}
+
+// SettleChange → kalau amountDue positif di-charge dulu sebelum apply (dan di-refund lagi kalau apply gagal),
+// kalau negatif selisihnya di-refund setelah apply berhasil
+func SettleChange(ctx context.Context, ps *PaymentService, booking models.Booking, amountDue float64, paymentToken, reason string, apply func(paymentID *primitive.ObjectID) error) (Settlement, error) {
+	var settlement Settlement
+	amountDue = round2(amountDue)
+
+	if amountDue > 0 {
+		if paymentToken == "" {
+			return settlement, ErrPaymentRequired
+		}
+		payment, err := ps.Charge(ctx, booking, amountDue, paymentToken)
+		if err != nil {
+			return settlement, fmt.Errorf("%w: %v", ErrPaymentFailed, err)
+		}
+		settlement.Payment = &payment
+
+		if err := apply(&payment.ID); err != nil {
+			if _, refundErr := ps.RefundPayment(ctx, payment, payment.Amount, reason+" failed"); refundErr != nil {
+				return settlement, fmt.Errorf("%v (refund of charge also failed: %v)", err, refundErr)
+			}
+			return settlement, err
+		}
+		return settlement, nil
+	}
+
+	if err := apply(nil); err != nil {
+		return settlement, err
+	}
+
+	if amountDue < 0 {
+		refunded, err := ps.RefundBooking(ctx, booking.ID, -amountDue, reason)
+		settlement.Refunded = refunded
+		if err != nil {
+			return settlement, fmt.Errorf("change applied but refund failed: %v", err)
+		}
+	}
+	return settlement, nil
+}
+
+// recordBookingChange → event booking.updated untuk perubahan kursi / flight, ditulis di transaksi yang sama
+func recordBookingChange(ctx context.Context, db *mongo.Database, bookingID primitive.ObjectID, change models.BookingChange) error {
+	return RecordEvent(ctx, db, events.BookingUpdated, events.AggregateBooking, bookingID, map[string]interface{}{
+		"changeType":   change.Type,
+		"fromFlightId": change.FromFlightID.Hex(),
+		"toFlightId":   change.ToFlightID.Hex(),
+		"actor":        change.Actor,
+	})
+}
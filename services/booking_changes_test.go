@@ -0,0 +1,85 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"airplane_booking_go/models"
+)
+
+func TestPlanSeatChange(t *testing.T) {
+	future := time.Now().Add(24 * time.Hour)
+	booking := models.Booking{Seats: []models.Seat{
+		{Number: "10A", Class: "economy", Price: 100},
+		{Number: "10B", Class: "economy", Price: 100},
+	}}
+	seats := []models.Seat{
+		{Number: "1A", Class: "economy", IsAvailable: true, Price: 150},
+		{Number: "12A", Class: "economy", IsAvailable: true, Price: 80},
+		{Number: "12B", Class: "economy", IsAvailable: false, Price: 80},
+		{Number: "12C", Class: "economy", IsAvailable: true, Blocked: true, Price: 80},
+	}
+	flight := func(status models.FlightStatus, depart time.Time) models.Flight {
+		return models.Flight{FlightNumber: "GA100", Status: status, DepartureTime: depart, Seats: seats}
+	}
+
+	tests := []struct {
+		name       string
+		flight     models.Flight
+		swaps      []models.SeatSwap
+		wantSeats  []string
+		wantDiff   float64
+		wantErr    bool
+		notBooking bool // error harus ErrFlightNotBookable
+	}{
+		{
+			name:      "upgrade one seat",
+			flight:    flight(models.FlightStatusScheduled, future),
+			swaps:     []models.SeatSwap{{From: "10A", To: "1A"}},
+			wantSeats: []string{"1A", "10B"},
+			wantDiff:  50,
+		},
+		{
+			name:      "cheaper seat gives negative difference",
+			flight:    flight(models.FlightStatusDelayed, future),
+			swaps:     []models.SeatSwap{{From: "10B", To: "12A"}},
+			wantSeats: []string{"10A", "12A"},
+			wantDiff:  -20,
+		},
+		{name: "seat not in booking", flight: flight("", future), swaps: []models.SeatSwap{{From: "9A", To: "1A"}}, wantErr: true},
+		{name: "same seat changed twice", flight: flight("", future), swaps: []models.SeatSwap{{From: "10A", To: "1A"}, {From: "10A", To: "12A"}}, wantErr: true},
+		{name: "target taken", flight: flight("", future), swaps: []models.SeatSwap{{From: "10A", To: "12B"}}, wantErr: true},
+		{name: "target blocked", flight: flight("", future), swaps: []models.SeatSwap{{From: "10A", To: "12C"}}, wantErr: true},
+		{name: "target claimed twice", flight: flight("", future), swaps: []models.SeatSwap{{From: "10A", To: "1A"}, {From: "10B", To: "1A"}}, wantErr: true},
+		{name: "flight departed", flight: flight(models.FlightStatusDeparted, future), swaps: []models.SeatSwap{{From: "10A", To: "1A"}}, wantErr: true, notBooking: true},
+		{name: "flight arrived", flight: flight(models.FlightStatusArrived, future), swaps: []models.SeatSwap{{From: "10A", To: "1A"}}, wantErr: true, notBooking: true},
+		{name: "flight cancelled", flight: flight(models.FlightStatusCancelled, future), swaps: []models.SeatSwap{{From: "10A", To: "1A"}}, wantErr: true, notBooking: true},
+		{name: "departure time passed", flight: flight(models.FlightStatusScheduled, time.Now().Add(-time.Hour)), swaps: []models.SeatSwap{{From: "10A", To: "1A"}}, wantErr: true, notBooking: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := PlanSeatChange(booking, tt.flight, tt.swaps)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("PlanSeatChange() error = nil, want error")
+				}
+				if tt.notBooking && !errors.Is(err, ErrFlightNotBookable) {
+					t.Errorf("PlanSeatChange() error = %v, want ErrFlightNotBookable", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PlanSeatChange() error = %v", err)
+			}
+			if got := seatNumbers(plan.NewSeats); !reflect.DeepEqual(got, tt.wantSeats) {
+				t.Errorf("NewSeats = %v, want %v", got, tt.wantSeats)
+			}
+			if plan.FareDifference != tt.wantDiff {
+				t.Errorf("FareDifference = %v, want %v", plan.FareDifference, tt.wantDiff)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"airplane_booking_go/events"
+	"airplane_booking_go/models"
+)
+
+// ActorSystem → actor buat transisi yang dilakukan job background
+const ActorSystem = "system"
+
+var (
+	ErrIllegalTransition = errors.New("illegal booking status transition")
+	ErrBookingChanged    = errors.New("booking was modified concurrently")
+)
+
+// Transition → perubahan status booking beserta field tambahan yang ikut di-update
+type Transition struct {
+	To     models.BookingStatus
+	Actor  string
+	Reason string
+	Filter bson.M   // kondisi tambahan selain status
+	Set    bson.M   // field tambahan yang di-$set
+	Unset  []string // field yang di-$unset
+}
+
+// TransitionBooking → satu-satunya jalan buat ganti status booking.
+// Transisi ilegal ditolak, setiap transisi dicatat di statusHistory dan ditulis ke outbox
+// dalam transaksi yang sama (ikut transaksi pemanggil kalau ada).
+func TransitionBooking(ctx context.Context, bookingColl *mongo.Collection, bookingID primitive.ObjectID, from models.BookingStatus, t Transition) error {
+	if !from.CanTransitionTo(t.To) {
+		return fmt.Errorf("%w: %s → %s", ErrIllegalTransition, from, t.To)
+	}
+
+	now := time.Now()
+	filter := bson.M{"_id": bookingID, "status": from}
+	for k, v := range t.Filter {
+		filter[k] = v
+	}
+
+	set := bson.M{"status": t.To, "updatedAt": now}
+	for k, v := range t.Set {
+		set[k] = v
+	}
+
+	update := bson.M{
+		"$set": set,
+		"$push": bson.M{"statusHistory": models.StatusChange{
+			From:   from,
+			To:     t.To,
+			Actor:  t.Actor,
+			Reason: t.Reason,
+			At:     now,
+		}},
+	}
+	if len(t.Unset) > 0 {
+		unset := bson.M{}
+		for _, field := range t.Unset {
+			unset[field] = ""
+		}
+		update["$unset"] = unset
+	}
+
+	return InTransaction(ctx, bookingColl.Database().Client(), func(ctx context.Context) error {
+		result, err := bookingColl.UpdateOne(ctx, filter, update)
+		if err != nil {
+			return err
+		}
+		if result.ModifiedCount == 0 {
+			return ErrBookingChanged
+		}
+		return RecordEvent(ctx, bookingColl.Database(), bookingStatusEvent(t.To), events.AggregateBooking, bookingID, map[string]interface{}{
+			"from":   string(from),
+			"to":     string(t.To),
+			"actor":  t.Actor,
+			"reason": t.Reason,
+		})
+	})
+}
+
+// bookingStatusEvent → tipe event untuk status tujuan, status lain cukup booking.updated
+func bookingStatusEvent(status models.BookingStatus) string {
+	switch status {
+	case models.BookingStatusConfirmed:
+		return events.BookingConfirmed
+	case models.BookingStatusCancelled:
+		return events.BookingCancelled
+	case models.BookingStatusRefunded:
+		return events.BookingRefunded
+	case models.BookingStatusExpired:
+		return events.BookingExpired
+	}
+	return events.BookingUpdated
+}
+
+// NewBooking → booking baru status pending/held, kursi dikunci sampai holdExpiresAt
+func NewBooking(userID primitive.ObjectID, recordLocator string, hold bool, holdTTL time.Duration) models.Booking {
+	now := time.Now()
+	expiresAt := now.Add(holdTTL)
+
+	booking := models.Booking{
+		ID:            primitive.NewObjectID(),
+		RecordLocator: recordLocator,
+		UserID:        userID,
+		Status:        models.BookingStatusPending, // jadi confirmed setelah payment di-capture
+		HoldExpiresAt: &expiresAt,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if hold {
+		booking.Status = models.BookingStatusHeld
+	}
+	booking.StatusHistory = []models.StatusChange{{
+		To:     booking.Status,
+		Actor:  userID.Hex(),
+		Reason: "booking created",
+		At:     now,
+	}}
+	return booking
+}
+
+// RecordBookingCreated → event booking.created, panggil di transaksi yang sama dengan insert booking
+func RecordBookingCreated(ctx context.Context, bookingColl *mongo.Collection, booking models.Booking, source string) error {
+	return RecordEvent(ctx, bookingColl.Database(), events.BookingCreated, events.AggregateBooking, booking.ID, map[string]interface{}{
+		"actor":  booking.UserID.Hex(),
+		"source": source, // booking, itinerary, waitlist
+	})
+}
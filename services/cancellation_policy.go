@@ -0,0 +1,166 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"airplane_booking_go/models"
+)
+
+// CancellationRule → berlaku kalau cancel minimal MinHoursBeforeDeparture jam sebelum departure.
+// Nilai negatif berarti masih berlaku setelah pesawat berangkat (no-show).
+type CancellationRule struct {
+	MinHoursBeforeDeparture float64 `json:"minHoursBeforeDeparture"`
+	RefundPercent           float64 `json:"refundPercent"`
+	FixedFee                float64 `json:"fixedFee"` // dipotong per kursi
+}
+
+// CancellationPolicy → aturan refund untuk satu seat class ("*" = default)
+type CancellationPolicy struct {
+	Class string             `json:"class"`
+	Rules []CancellationRule `json:"rules"`
+}
+
+type CancellationPolicies struct {
+	policies map[string]CancellationPolicy
+}
+
+// RefundQuote → hasil hitung refund sebelum/sesudah cancel
+type RefundQuote struct {
+	HoursBeforeDeparture float64      `json:"hoursBeforeDeparture"`
+	TotalPaid            float64      `json:"totalPaid"`
+	Fees                 float64      `json:"fees"`
+	RefundAmount         float64      `json:"refundAmount"`
+	Seats                []SeatRefund `json:"seats"`
+}
+
+type SeatRefund struct {
+	Number        string  `json:"number"`
+	Class         string  `json:"class"`
+	Price         float64 `json:"price"`
+	RefundPercent float64 `json:"refundPercent"`
+	Fee           float64 `json:"fee"`
+	Refund        float64 `json:"refund"`
+}
+
+// DefaultCancellationPolicies → dipakai kalau gak ada file policy
+func DefaultCancellationPolicies() []CancellationPolicy {
+	return []CancellationPolicy{
+		{
+			Class: "economy",
+			Rules: []CancellationRule{
+				{MinHoursBeforeDeparture: 72, RefundPercent: 100},
+				{MinHoursBeforeDeparture: 0, RefundPercent: 50},
+			},
+		},
+		{
+			Class: "business",
+			Rules: []CancellationRule{
+				{MinHoursBeforeDeparture: 24, RefundPercent: 100},
+				{MinHoursBeforeDeparture: 0, RefundPercent: 75},
+			},
+		},
+		{
+			Class: "*",
+			Rules: []CancellationRule{
+				{MinHoursBeforeDeparture: 72, RefundPercent: 100},
+				{MinHoursBeforeDeparture: 0, RefundPercent: 50},
+			},
+		},
+	}
+}
+
+func NewCancellationPolicies(policies []CancellationPolicy) *CancellationPolicies {
+	byClass := map[string]CancellationPolicy{}
+	for _, policy := range policies {
+		rules := append([]CancellationRule(nil), policy.Rules...)
+		// rule dengan jam terbesar dicek duluan
+		sort.Slice(rules, func(i, j int) bool {
+			return rules[i].MinHoursBeforeDeparture > rules[j].MinHoursBeforeDeparture
+		})
+		policy.Rules = rules
+		byClass[strings.ToLower(policy.Class)] = policy
+	}
+	return &CancellationPolicies{policies: byClass}
+}
+
+// LoadCancellationPolicies → baca policy dari file JSON (array CancellationPolicy), path kosong = default
+func LoadCancellationPolicies(path string) (*CancellationPolicies, error) {
+	if path == "" {
+		return NewCancellationPolicies(DefaultCancellationPolicies()), nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []CancellationPolicy
+	if err := json.Unmarshal(raw, &policies); err != nil {
+		return nil, fmt.Errorf("invalid cancellation policy file: %v", err)
+	}
+	for _, policy := range policies {
+		for _, rule := range policy.Rules {
+			if rule.RefundPercent < 0 || rule.RefundPercent > 100 || rule.FixedFee < 0 {
+				return nil, fmt.Errorf("invalid rule in cancellation policy %q", policy.Class)
+			}
+		}
+	}
+	return NewCancellationPolicies(policies), nil
+}
+
+// Quote → hitung refund booking kalau di-cancel pada waktu now.
+// Tiap segmen dievaluasi terhadap departure flight-nya masing-masing.
+func (p *CancellationPolicies) Quote(booking models.Booking, flights map[primitive.ObjectID]models.Flight, now time.Time) RefundQuote {
+	var quote RefundQuote
+
+	for i, segment := range booking.AllSegments() {
+		hoursBefore := math.Round(flights[segment.FlightID].DepartureTime.Sub(now).Hours()*100) / 100
+		if i == 0 {
+			quote.HoursBeforeDeparture = hoursBefore
+		}
+
+		for _, seat := range segment.Seats {
+			seatRefund := SeatRefund{Number: seat.Number, Class: seat.Class, Price: seat.Price}
+			if rule, ok := p.ruleFor(seat.Class, hoursBefore); ok {
+				seatRefund.RefundPercent = rule.RefundPercent
+				seatRefund.Fee = rule.FixedFee
+				seatRefund.Refund = round2(math.Max(0, seat.Price*rule.RefundPercent/100-rule.FixedFee))
+			}
+
+			quote.TotalPaid += seat.Price
+			quote.Fees += seatRefund.Fee
+			quote.RefundAmount += seatRefund.Refund
+			quote.Seats = append(quote.Seats, seatRefund)
+		}
+	}
+
+	quote.TotalPaid = round2(quote.TotalPaid)
+	quote.Fees = round2(quote.Fees)
+	quote.RefundAmount = round2(math.Min(quote.RefundAmount, booking.TotalPrice))
+	return quote
+}
+
+func (p *CancellationPolicies) ruleFor(class string, hoursBefore float64) (CancellationRule, bool) {
+	policy, ok := p.policies[strings.ToLower(class)]
+	if !ok {
+		policy, ok = p.policies["*"]
+		if !ok {
+			return CancellationRule{}, false
+		}
+	}
+
+	for _, rule := range policy.Rules {
+		if hoursBefore >= rule.MinHoursBeforeDeparture {
+			return rule, true
+		}
+	}
+	return CancellationRule{}, false
+}
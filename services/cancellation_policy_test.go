@@ -0,0 +1,233 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"airplane_booking_go/models"
+)
+
+func TestCancellationQuote(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	flightID := primitive.NewObjectID()
+	returnID := primitive.NewObjectID()
+
+	singleSeat := func(class string, price float64) models.Booking {
+		return models.Booking{
+			FlightID:   flightID,
+			Seats:      []models.Seat{{Number: "1A", Class: class, Price: price}},
+			TotalPrice: price,
+		}
+	}
+
+	custom := NewCancellationPolicies([]CancellationPolicy{
+		{Class: "economy", Rules: []CancellationRule{
+			{MinHoursBeforeDeparture: 0, RefundPercent: 10, FixedFee: 30},
+			{MinHoursBeforeDeparture: 48, RefundPercent: 100, FixedFee: 25},
+			{MinHoursBeforeDeparture: -24, RefundPercent: 5},
+		}},
+	})
+
+	tests := []struct {
+		name        string
+		policies    *CancellationPolicies
+		booking     models.Booking
+		departures  map[primitive.ObjectID]time.Duration // departure - now
+		wantHours   float64
+		wantFees    float64
+		wantRefund  float64
+		wantPercent []float64
+	}{
+		{
+			name:        "economy well before departure",
+			policies:    NewCancellationPolicies(DefaultCancellationPolicies()),
+			booking:     singleSeat("economy", 200),
+			departures:  map[primitive.ObjectID]time.Duration{flightID: 100 * time.Hour},
+			wantHours:   100,
+			wantRefund:  200,
+			wantPercent: []float64{100},
+		},
+		{
+			name:        "economy inside 72 hours",
+			policies:    NewCancellationPolicies(DefaultCancellationPolicies()),
+			booking:     singleSeat("economy", 200),
+			departures:  map[primitive.ObjectID]time.Duration{flightID: 10 * time.Hour},
+			wantHours:   10,
+			wantRefund:  100,
+			wantPercent: []float64{50},
+		},
+		{
+			name:        "business inside 24 hours",
+			policies:    NewCancellationPolicies(DefaultCancellationPolicies()),
+			booking:     singleSeat("Business", 400),
+			departures:  map[primitive.ObjectID]time.Duration{flightID: 10 * time.Hour},
+			wantHours:   10,
+			wantRefund:  300,
+			wantPercent: []float64{75},
+		},
+		{
+			name:        "unknown class falls back to default policy",
+			policies:    NewCancellationPolicies(DefaultCancellationPolicies()),
+			booking:     singleSeat("first", 1000),
+			departures:  map[primitive.ObjectID]time.Duration{flightID: 80 * time.Hour},
+			wantHours:   80,
+			wantRefund:  1000,
+			wantPercent: []float64{100},
+		},
+		{
+			name:        "no refund after departure without no-show rule",
+			policies:    NewCancellationPolicies(DefaultCancellationPolicies()),
+			booking:     singleSeat("economy", 200),
+			departures:  map[primitive.ObjectID]time.Duration{flightID: -time.Hour},
+			wantHours:   -1,
+			wantRefund:  0,
+			wantPercent: []float64{0},
+		},
+		{
+			name:        "rules are ordered by hours and fee is deducted per seat",
+			policies:    custom,
+			booking:     singleSeat("economy", 200),
+			departures:  map[primitive.ObjectID]time.Duration{flightID: 50 * time.Hour},
+			wantHours:   50,
+			wantFees:    25,
+			wantRefund:  175,
+			wantPercent: []float64{100},
+		},
+		{
+			name:        "fee larger than refund gives zero, not negative",
+			policies:    custom,
+			booking:     singleSeat("economy", 200),
+			departures:  map[primitive.ObjectID]time.Duration{flightID: 5 * time.Hour},
+			wantHours:   5,
+			wantFees:    30,
+			wantRefund:  0,
+			wantPercent: []float64{10},
+		},
+		{
+			name:        "no-show rule with negative hours",
+			policies:    custom,
+			booking:     singleSeat("economy", 200),
+			departures:  map[primitive.ObjectID]time.Duration{flightID: -3 * time.Hour},
+			wantHours:   -3,
+			wantRefund:  10,
+			wantPercent: []float64{5},
+		},
+		{
+			name:     "class without policy and no default refunds nothing",
+			policies: custom,
+			booking:  singleSeat("business", 200),
+			departures: map[primitive.ObjectID]time.Duration{
+				flightID: 100 * time.Hour,
+			},
+			wantHours:   100,
+			wantRefund:  0,
+			wantPercent: []float64{0},
+		},
+		{
+			name:     "each segment uses its own departure",
+			policies: NewCancellationPolicies(DefaultCancellationPolicies()),
+			booking: models.Booking{
+				FlightID: flightID,
+				Segments: []models.BookingSegment{
+					{FlightID: flightID, Seats: []models.Seat{{Number: "1A", Class: "economy", Price: 100}}},
+					{FlightID: returnID, Seats: []models.Seat{{Number: "2A", Class: "economy", Price: 100}}},
+				},
+				TotalPrice: 200,
+			},
+			departures: map[primitive.ObjectID]time.Duration{
+				flightID: 10 * time.Hour,
+				returnID: 200 * time.Hour,
+			},
+			wantHours:   10,
+			wantRefund:  150,
+			wantPercent: []float64{50, 100},
+		},
+		{
+			name:     "refund never exceeds what was paid",
+			policies: NewCancellationPolicies(DefaultCancellationPolicies()),
+			booking: models.Booking{
+				FlightID:   flightID,
+				Seats:      []models.Seat{{Number: "1A", Class: "economy", Price: 200}},
+				TotalPrice: 150,
+			},
+			departures:  map[primitive.ObjectID]time.Duration{flightID: 100 * time.Hour},
+			wantHours:   100,
+			wantRefund:  150,
+			wantPercent: []float64{100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flights := map[primitive.ObjectID]models.Flight{}
+			for id, d := range tt.departures {
+				flights[id] = models.Flight{ID: id, DepartureTime: now.Add(d)}
+			}
+
+			quote := tt.policies.Quote(tt.booking, flights, now)
+			if quote.HoursBeforeDeparture != tt.wantHours {
+				t.Errorf("HoursBeforeDeparture = %v, want %v", quote.HoursBeforeDeparture, tt.wantHours)
+			}
+			if quote.Fees != tt.wantFees {
+				t.Errorf("Fees = %v, want %v", quote.Fees, tt.wantFees)
+			}
+			if quote.RefundAmount != tt.wantRefund {
+				t.Errorf("RefundAmount = %v, want %v", quote.RefundAmount, tt.wantRefund)
+			}
+			if len(quote.Seats) != len(tt.wantPercent) {
+				t.Fatalf("got %d seat refunds, want %d", len(quote.Seats), len(tt.wantPercent))
+			}
+			for i, seat := range quote.Seats {
+				if seat.RefundPercent != tt.wantPercent[i] {
+					t.Errorf("seat %s RefundPercent = %v, want %v", seat.Number, seat.RefundPercent, tt.wantPercent[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLoadCancellationPolicies(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{name: "empty path uses defaults", path: ""},
+		{name: "valid file", path: write("valid.json", `[{"class":"*","rules":[{"minHoursBeforeDeparture":0,"refundPercent":80,"fixedFee":10}]}]`)},
+		{name: "missing file", path: filepath.Join(dir, "missing.json"), wantErr: true},
+		{name: "invalid json", path: write("broken.json", `{`), wantErr: true},
+		{name: "percent above 100", path: write("percent.json", `[{"class":"*","rules":[{"refundPercent":150}]}]`), wantErr: true},
+		{name: "negative fee", path: write("fee.json", `[{"class":"*","rules":[{"refundPercent":50,"fixedFee":-1}]}]`), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policies, err := LoadCancellationPolicies(tt.path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("LoadCancellationPolicies() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadCancellationPolicies() error = %v", err)
+			}
+			if policies == nil {
+				t.Fatal("LoadCancellationPolicies() = nil")
+			}
+		})
+	}
+}
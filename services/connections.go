@@ -0,0 +1,338 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"airplane_booking_go/models"
+)
+
+// batas kombinasi per leg pertama (dan jumlah hasil terbaik yang disimpan kalau Limit kosong),
+// biar rute padat gak meledak
+const maxItineraryCandidates = 1000
+
+// ItinerarySearch → parameter pencarian itinerary asal → tujuan (direct + connecting)
+type ItinerarySearch struct {
+	From           models.Airport
+	To             models.Airport
+	Date           time.Time // 00:00 tanggal berangkat, zona waktu bandara asal
+	Passengers     int
+	Class          string // kosong = class apa saja
+	MaxConnections int
+	MaxTravelTime  time.Duration // berangkat pertama → tiba terakhir
+	MinConnection  time.Duration // default kalau bandara transit gak punya minConnectionMinutes
+	SortBy         string        // price (default) | duration
+	Limit          int
+}
+
+// ItineraryLeg → satu flight di itinerary, Price = total untuk semua penumpang di Class yang sama
+type ItineraryLeg struct {
+	FlightID           string         `json:"flightId"`
+	Airline            string         `json:"airline"`
+	CarrierCode        string         `json:"carrierCode,omitempty"`
+	FlightNumber       string         `json:"flightNumber"`
+	Departure          models.Airport `json:"departure"`
+	Arrival            models.Airport `json:"arrival"`
+	DepartureTime      time.Time      `json:"departureTime"`
+	ArrivalTime        time.Time      `json:"arrivalTime"`
+	DepartureLocalTime string         `json:"departureLocalTime,omitempty"`
+	ArrivalLocalTime   string         `json:"arrivalLocalTime,omitempty"`
+	Duration           int            `json:"duration"`
+	Class              string         `json:"class"`
+	Price              float64        `json:"price"`
+	AvailableSeats     int            `json:"availableSeats"`
+}
+
+// Layover → transit di antara dua leg
+type Layover struct {
+	Airport string `json:"airport"`
+	Minutes int    `json:"minutes"`
+}
+
+// Itinerary → satu pilihan perjalanan, Duration dalam menit (termasuk transit)
+type Itinerary struct {
+	Class         string         `json:"class"`
+	Legs          []ItineraryLeg `json:"legs"`
+	Connections   int            `json:"connections"`
+	Layovers      []Layover      `json:"layovers"`
+	DepartureTime time.Time      `json:"departureTime"`
+	ArrivalTime   time.Time      `json:"arrivalTime"`
+	Duration      int            `json:"duration"`
+	TotalPrice    float64        `json:"totalPrice"`
+}
+
+// SearchItineraries → semua itinerary dengan maksimal MaxConnections transit, tiap transit minimal
+// waktu transit bandara itu, total perjalanan maksimal MaxTravelTime. Diurutkan harga lalu durasi
+// (atau sebaliknya kalau SortBy = duration).
+func SearchItineraries(ctx context.Context, flightColl, airportColl *mongo.Collection, search ItinerarySearch) ([]Itinerary, error) {
+	dayStart := search.Date
+	dayEnd := dayStart.AddDate(0, 0, 1)
+	from := dayStart
+	if now := time.Now(); now.After(from) {
+		from = now
+	}
+	if !from.Before(dayEnd) {
+		return []Itinerary{}, nil
+	}
+
+	cursor, err := flightColl.Find(ctx, bson.M{
+		"departureTime": bson.M{"$gte": from.UTC(), "$lt": dayEnd.Add(search.MaxTravelTime).UTC()},
+		"status":        bson.M{"$nin": nonBookableStatuses()},
+	}, options.Find().SetSort(bson.D{{Key: "departureTime", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var flights []models.Flight
+	if err := cursor.All(ctx, &flights); err != nil {
+		return nil, err
+	}
+
+	// leg yang masih punya kursi cukup, satu leg per class, dikelompokkan per bandara asal
+	byOrigin := map[string][]ItineraryLeg{}
+	for _, flight := range flights {
+		flight = flight.WithLocalTimes()
+		for _, fare := range legFares(flight, search.Class, search.Passengers) {
+			byOrigin[flight.Departure.Code] = append(byOrigin[flight.Departure.Code], ItineraryLeg{
+				FlightID:           flight.ID.Hex(),
+				Airline:            flight.Airline,
+				CarrierCode:        flight.CarrierCode,
+				FlightNumber:       flight.FlightNumber,
+				Departure:          flight.Departure,
+				Arrival:            flight.Arrival,
+				DepartureTime:      flight.DepartureTime,
+				ArrivalTime:        flight.ArrivalTime,
+				DepartureLocalTime: flight.DepartureLocalTime,
+				ArrivalLocalTime:   flight.ArrivalLocalTime,
+				Duration:           flight.Duration,
+				Class:              fare.Class,
+				Price:              fare.Price,
+				AvailableSeats:     fare.Available,
+			})
+		}
+	}
+
+	hubs := make([]string, 0, len(byOrigin))
+	for code := range byOrigin {
+		hubs = append(hubs, code)
+	}
+	minConnection, err := MinConnectionTimes(ctx, airportColl, hubs, search.MinConnection)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildItineraries(byOrigin, search, minConnection), nil
+}
+
+// buildItineraries → jelajahi leg dari bandara asal (urut departureTime per bandara) sampai tujuan
+// dengan class yang sama di semua leg. Hasil terbaik (sesuai SortBy) disimpan terurut sambil jalan,
+// cabang yang sudah pasti kalah dari hasil terburuk yang disimpan gak dijelajahi lagi.
+func buildItineraries(byOrigin map[string][]ItineraryLeg, search ItinerarySearch, minConnection func(string) time.Duration) []Itinerary {
+	dayEnd := search.Date.AddDate(0, 0, 1)
+	keep := search.Limit
+	if keep <= 0 || keep > maxItineraryCandidates {
+		keep = maxItineraryCandidates
+	}
+
+	results := []Itinerary{}
+	found := 0 // kombinasi yang ketemu dari leg pertama yang sedang dijelajahi
+	var walk func(legs []ItineraryLeg, visited map[string]bool)
+	walk = func(legs []ItineraryLeg, visited map[string]bool) {
+		if found >= maxItineraryCandidates {
+			return
+		}
+		// harga dan durasi cuma bisa naik kalau leg ditambah
+		if len(results) == keep && partialWorse(legs, results[keep-1], search.SortBy) {
+			return
+		}
+		last := legs[len(legs)-1]
+		if last.Arrival.Code == search.To.Code {
+			found++
+			results = insertItinerary(results, newItinerary(legs), search.SortBy, keep)
+			return
+		}
+		if len(legs) > search.MaxConnections {
+			return
+		}
+
+		hub := last.Arrival.Code
+		earliest := last.ArrivalTime.Add(minConnection(hub))
+		latestArrival := legs[0].DepartureTime.Add(search.MaxTravelTime)
+		for _, next := range byOrigin[hub] {
+			// satu itinerary = satu class, biar totalnya bisa dibooking apa adanya
+			if next.Class != last.Class || next.DepartureTime.Before(earliest) || visited[next.Arrival.Code] {
+				continue
+			}
+			if next.DepartureTime.After(latestArrival) {
+				break // urut departureTime, sisanya pasti kelewat batas
+			}
+			if next.ArrivalTime.After(latestArrival) {
+				continue
+			}
+			visited[next.Arrival.Code] = true
+			walk(append(legs, next), visited)
+			delete(visited, next.Arrival.Code)
+		}
+	}
+
+	for _, first := range byOrigin[search.From.Code] {
+		if !first.DepartureTime.Before(dayEnd) {
+			break
+		}
+		if first.ArrivalTime.Sub(first.DepartureTime) > search.MaxTravelTime {
+			continue
+		}
+		found = 0
+		walk([]ItineraryLeg{first}, map[string]bool{search.From.Code: true, first.Arrival.Code: true})
+	}
+	return results
+}
+
+// insertItinerary → sisipkan ke results yang sudah terurut, yang setara tetap urut ketemu, potong di keep
+func insertItinerary(results []Itinerary, itinerary Itinerary, sortBy string, keep int) []Itinerary {
+	i := sort.Search(len(results), func(i int) bool { return itineraryLess(itinerary, results[i], sortBy) })
+	if i >= keep {
+		return results
+	}
+	results = append(results, Itinerary{})
+	copy(results[i+1:], results[i:])
+	results[i] = itinerary
+	if len(results) > keep {
+		results = results[:keep]
+	}
+	return results
+}
+
+// partialWorse → leg yang sudah dipilih sudah lebih mahal / lebih lama dari worst di kunci urut utama
+func partialWorse(legs []ItineraryLeg, worst Itinerary, sortBy string) bool {
+	if sortBy == "duration" {
+		elapsed := int(legs[len(legs)-1].ArrivalTime.Sub(legs[0].DepartureTime) / time.Minute)
+		return elapsed > worst.Duration
+	}
+	price := 0.0
+	for _, leg := range legs {
+		price += leg.Price
+	}
+	return price > worst.TotalPrice
+}
+
+func newItinerary(legs []ItineraryLeg) Itinerary {
+	itinerary := Itinerary{
+		Class:         legs[0].Class,
+		Legs:          append([]ItineraryLeg(nil), legs...),
+		Connections:   len(legs) - 1,
+		Layovers:      []Layover{},
+		DepartureTime: legs[0].DepartureTime,
+		ArrivalTime:   legs[len(legs)-1].ArrivalTime,
+	}
+	for i, leg := range legs {
+		itinerary.TotalPrice += leg.Price
+		if i > 0 {
+			itinerary.Layovers = append(itinerary.Layovers, Layover{
+				Airport: leg.Departure.Code,
+				Minutes: int(leg.DepartureTime.Sub(legs[i-1].ArrivalTime) / time.Minute),
+			})
+		}
+	}
+	itinerary.Duration = int(itinerary.ArrivalTime.Sub(itinerary.DepartureTime) / time.Minute)
+	return itinerary
+}
+
+// itineraryLess → harga lalu durasi lalu jumlah transit (durasi duluan kalau sortBy = duration)
+func itineraryLess(a, b Itinerary, sortBy string) bool {
+	if sortBy == "duration" {
+		if a.Duration != b.Duration {
+			return a.Duration < b.Duration
+		}
+		return a.TotalPrice < b.TotalPrice
+	}
+	if a.TotalPrice != b.TotalPrice {
+		return a.TotalPrice < b.TotalPrice
+	}
+	if a.Duration != b.Duration {
+		return a.Duration < b.Duration
+	}
+	return a.Connections < b.Connections
+}
+
+// legFare → harga satu leg untuk semua penumpang di satu class
+type legFare struct {
+	Class     string
+	Price     float64
+	Available int
+}
+
+// legFares → total harga kursi termurah untuk semua penumpang, per class yang kursinya masih cukup
+// (class kosong = semua class), urut nama class
+func legFares(flight models.Flight, class string, passengers int) []legFare {
+	byClass := map[string][]float64{}
+	for _, seat := range flight.Seats {
+		if seat.IsBookable() && (class == "" || seat.Class == class) {
+			byClass[seat.Class] = append(byClass[seat.Class], seat.Price)
+		}
+	}
+
+	var fares []legFare
+	for seatClass, prices := range byClass {
+		if len(prices) < passengers {
+			continue
+		}
+		sort.Float64s(prices)
+		total := 0.0
+		for _, price := range prices[:passengers] {
+			total += price
+		}
+		fares = append(fares, legFare{Class: seatClass, Price: total, Available: len(prices)})
+	}
+	sort.Slice(fares, func(i, j int) bool { return fares[i].Class < fares[j].Class })
+	return fares
+}
+
+// MinConnectionTimes → waktu transit minimum per bandara (minConnectionMinutes di collection airports),
+// bandara yang gak punya pakai fallback. Dipakai search itinerary dan validasi booking itinerary.
+func MinConnectionTimes(ctx context.Context, airportColl *mongo.Collection, codes []string, fallback time.Duration) (func(string) time.Duration, error) {
+	cursor, err := airportColl.Find(ctx,
+		bson.M{"code": bson.M{"$in": codes}, "minConnectionMinutes": bson.M{"$gt": 0}},
+		options.Find().SetProjection(bson.M{"code": 1, "minConnectionMinutes": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var records []models.AirportRecord
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+	times := map[string]time.Duration{}
+	for _, record := range records {
+		times[record.Code] = time.Duration(record.MinConnectionMinutes) * time.Minute
+	}
+
+	return func(code string) time.Duration {
+		if d, ok := times[code]; ok {
+			return d
+		}
+		return fallback
+	}, nil
+}
+
+// nonBookableStatuses → status flight yang gak bisa dibooking lagi
+func nonBookableStatuses() []models.FlightStatus {
+	var statuses []models.FlightStatus
+	for _, status := range []models.FlightStatus{
+		models.FlightStatusScheduled, models.FlightStatusBoarding, models.FlightStatusDelayed, models.FlightStatusDeparted,
+		models.FlightStatusArrived, models.FlightStatusCancelled, models.FlightStatusDiverted,
+	} {
+		if !status.AcceptsBookings() {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
@@ -0,0 +1,322 @@
+package services
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"airplane_booking_go/models"
+)
+
+var searchDay = time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+
+// testLeg → leg economy dari..ke, jam dalam jam sejak searchDay
+func testLeg(number, from, to string, dep, arr float64, price float64) ItineraryLeg {
+	return ItineraryLeg{
+		FlightNumber:  number,
+		Departure:     models.Airport{Code: from},
+		Arrival:       models.Airport{Code: to},
+		DepartureTime: searchDay.Add(time.Duration(dep * float64(time.Hour))),
+		ArrivalTime:   searchDay.Add(time.Duration(arr * float64(time.Hour))),
+		Class:         "economy",
+		Price:         price,
+	}
+}
+
+// byOriginOf → sama seperti SearchItineraries: leg per bandara asal, urut departureTime
+func byOriginOf(legs ...ItineraryLeg) map[string][]ItineraryLeg {
+	sorted := append([]ItineraryLeg(nil), legs...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].DepartureTime.Before(sorted[j].DepartureTime) })
+
+	byOrigin := map[string][]ItineraryLeg{}
+	for _, leg := range sorted {
+		byOrigin[leg.Departure.Code] = append(byOrigin[leg.Departure.Code], leg)
+	}
+	return byOrigin
+}
+
+// itineraryNumbers → "GA1+GA2" per itinerary, urut hasil
+func itineraryNumbers(itineraries []Itinerary) []string {
+	var out []string
+	for _, itinerary := range itineraries {
+		s := ""
+		for i, leg := range itinerary.Legs {
+			if i > 0 {
+				s += "+"
+			}
+			s += leg.FlightNumber
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func TestBuildItineraries(t *testing.T) {
+	search := ItinerarySearch{
+		From:           models.Airport{Code: "CGK"},
+		To:             models.Airport{Code: "DPS"},
+		Date:           searchDay,
+		MaxConnections: 2,
+		MaxTravelTime:  12 * time.Hour,
+		SortBy:         "price",
+		Limit:          20,
+	}
+	mct := func(code string) time.Duration {
+		if code == "SUB" {
+			return time.Hour
+		}
+		return 45 * time.Minute
+	}
+	with := func(edit func(s *ItinerarySearch)) ItinerarySearch {
+		s := search
+		edit(&s)
+		return s
+	}
+
+	tests := []struct {
+		name   string
+		legs   []ItineraryLeg
+		search ItinerarySearch
+		want   []string
+	}{
+		{
+			name:   "direct and one-stop sorted by price",
+			search: search,
+			legs: []ItineraryLeg{
+				testLeg("DIRECT", "CGK", "DPS", 8, 10, 300),
+				testLeg("CGK-SUB", "CGK", "SUB", 8, 9, 100),
+				testLeg("SUB-DPS", "SUB", "DPS", 10, 11, 100),
+			},
+			want: []string{"CGK-SUB+SUB-DPS", "DIRECT"},
+		},
+		{
+			name:   "connection shorter than airport minimum is skipped",
+			search: search,
+			legs: []ItineraryLeg{
+				testLeg("CGK-SUB", "CGK", "SUB", 8, 9, 100),
+				testLeg("TIGHT", "SUB", "DPS", 9.5, 10.5, 100), // 30 menit < 60 menit di SUB
+				testLeg("OK", "SUB", "DPS", 10, 11, 150),
+			},
+			want: []string{"CGK-SUB+OK"},
+		},
+		{
+			name:   "max connections respected",
+			search: with(func(s *ItinerarySearch) { s.MaxConnections = 1 }),
+			legs: []ItineraryLeg{
+				testLeg("A", "CGK", "SUB", 6, 7, 10),
+				testLeg("B", "SUB", "UPG", 8, 9, 10),
+				testLeg("C", "UPG", "DPS", 10, 11, 10),
+				testLeg("D", "SUB", "DPS", 8, 9, 100),
+			},
+			want: []string{"A+D"},
+		},
+		{
+			name:   "two connections allowed by default",
+			search: search,
+			legs: []ItineraryLeg{
+				testLeg("A", "CGK", "SUB", 6, 7, 10),
+				testLeg("B", "SUB", "UPG", 8, 9, 10),
+				testLeg("C", "UPG", "DPS", 10, 11, 10),
+				testLeg("D", "SUB", "DPS", 8, 9, 100),
+			},
+			want: []string{"A+B+C", "A+D"},
+		},
+		{
+			name:   "no revisiting airports",
+			search: search,
+			legs: []ItineraryLeg{
+				testLeg("A", "CGK", "SUB", 6, 7, 10),
+				testLeg("BACK", "SUB", "CGK", 8, 9, 10),
+				testLeg("B", "CGK", "DPS", 10, 11, 10),
+			},
+			want: []string{"B"},
+		},
+		{
+			name:   "total travel time capped",
+			search: with(func(s *ItinerarySearch) { s.MaxTravelTime = 4 * time.Hour }),
+			legs: []ItineraryLeg{
+				testLeg("A", "CGK", "SUB", 6, 7, 10),
+				testLeg("LATE", "SUB", "DPS", 9, 10.5, 10), // tiba 4,5 jam setelah berangkat
+				testLeg("EARLY", "SUB", "DPS", 8, 9, 50),
+			},
+			want: []string{"A+EARLY"},
+		},
+		{
+			name:   "first leg must depart on the search date",
+			search: search,
+			legs: []ItineraryLeg{
+				testLeg("TODAY", "CGK", "DPS", 23, 25, 200),
+				testLeg("TOMORROW", "CGK", "DPS", 24, 26, 100),
+			},
+			want: []string{"TODAY"},
+		},
+		{
+			name:   "legs in different classes are not combined",
+			search: search,
+			legs: func() []ItineraryLeg {
+				business := testLeg("SUB-DPS-J", "SUB", "DPS", 10, 11, 50)
+				business.Class = "business"
+				return []ItineraryLeg{testLeg("CGK-SUB", "CGK", "SUB", 8, 9, 100), business}
+			}(),
+			want: nil,
+		},
+		{
+			name:   "sort by duration",
+			search: with(func(s *ItinerarySearch) { s.SortBy = "duration" }),
+			legs: []ItineraryLeg{
+				testLeg("DIRECT", "CGK", "DPS", 8, 10, 300),
+				testLeg("CGK-SUB", "CGK", "SUB", 8, 9, 100),
+				testLeg("SUB-DPS", "SUB", "DPS", 10, 11, 100),
+			},
+			want: []string{"DIRECT", "CGK-SUB+SUB-DPS"},
+		},
+		{
+			name:   "limit applied after sorting",
+			search: with(func(s *ItinerarySearch) { s.Limit = 1 }),
+			legs: []ItineraryLeg{
+				testLeg("EXPENSIVE", "CGK", "DPS", 7, 9, 500),
+				testLeg("CHEAP", "CGK", "DPS", 8, 10, 100),
+			},
+			want: []string{"CHEAP"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildItineraries(byOriginOf(tt.legs...), tt.search, mct)
+			if got == nil {
+				t.Fatal("buildItineraries() = nil, want empty slice")
+			}
+			if numbers := itineraryNumbers(got); !reflect.DeepEqual(numbers, tt.want) {
+				t.Errorf("buildItineraries() = %v, want %v", numbers, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewItinerary(t *testing.T) {
+	itinerary := newItinerary([]ItineraryLeg{
+		testLeg("A", "CGK", "SUB", 8, 9.5, 100),
+		testLeg("B", "SUB", "DPS", 11, 12, 120.5),
+	})
+
+	if itinerary.Connections != 1 || itinerary.Class != "economy" {
+		t.Errorf("Connections / Class = %d / %s, want 1 / economy", itinerary.Connections, itinerary.Class)
+	}
+	if itinerary.Duration != 240 {
+		t.Errorf("Duration = %d, want 240", itinerary.Duration)
+	}
+	if itinerary.TotalPrice != 220.5 {
+		t.Errorf("TotalPrice = %v, want 220.5", itinerary.TotalPrice)
+	}
+	if want := []Layover{{Airport: "SUB", Minutes: 90}}; !reflect.DeepEqual(itinerary.Layovers, want) {
+		t.Errorf("Layovers = %v, want %v", itinerary.Layovers, want)
+	}
+}
+
+func TestInsertItinerary(t *testing.T) {
+	itineraries := []Itinerary{
+		{Class: "a", TotalPrice: 200, Duration: 100, Connections: 0},
+		{Class: "b", TotalPrice: 100, Duration: 300, Connections: 1},
+		{Class: "c", TotalPrice: 100, Duration: 300, Connections: 0},
+		{Class: "d", TotalPrice: 100, Duration: 200, Connections: 2},
+		{Class: "e", TotalPrice: 100, Duration: 200, Connections: 2}, // sama persis dengan d → tetap di belakang d
+	}
+	order := func(sortBy string, keep int) string {
+		var list []Itinerary
+		for _, it := range itineraries {
+			list = insertItinerary(list, it, sortBy, keep)
+		}
+		s := ""
+		for _, it := range list {
+			s += it.Class
+		}
+		return s
+	}
+
+	tests := []struct {
+		sortBy string
+		keep   int
+		want   string
+	}{
+		{"price", 10, "decba"},
+		{"duration", 10, "adebc"},
+		{"price", 2, "de"},
+		{"duration", 1, "a"},
+	}
+	for _, tt := range tests {
+		if got := order(tt.sortBy, tt.keep); got != tt.want {
+			t.Errorf("insertItinerary(%s, keep %d) order = %s, want %s", tt.sortBy, tt.keep, got, tt.want)
+		}
+	}
+}
+
+func TestBuildItinerariesBeyondCandidateCap(t *testing.T) {
+	// lebih banyak direct mahal dari batas kandidat, yang murah baru berangkat malam
+	var legs []ItineraryLeg
+	for i := 0; i < maxItineraryCandidates+50; i++ {
+		legs = append(legs, testLeg("EXPENSIVE", "CGK", "DPS", float64(i)/100, float64(i)/100+2, 500))
+	}
+	legs = append(legs,
+		testLeg("CHEAP", "CGK", "DPS", 20, 22, 100),
+		testLeg("CGK-SUB", "CGK", "SUB", 19, 20, 50),
+		testLeg("SUB-DPS", "SUB", "DPS", 21, 22, 40),
+	)
+	search := ItinerarySearch{
+		From:           models.Airport{Code: "CGK"},
+		To:             models.Airport{Code: "DPS"},
+		Date:           searchDay,
+		MaxConnections: 1,
+		MaxTravelTime:  12 * time.Hour,
+		SortBy:         "price",
+		Limit:          3,
+	}
+	mct := func(string) time.Duration { return time.Hour }
+
+	got := itineraryNumbers(buildItineraries(byOriginOf(legs...), search, mct))
+	if want := []string{"CGK-SUB+SUB-DPS", "CHEAP", "EXPENSIVE"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("buildItineraries() = %v, want %v", got, want)
+	}
+
+	// tanpa Limit tetap semua kandidat dibandingkan, hasil dibatasi maxItineraryCandidates
+	search.Limit = 0
+	all := buildItineraries(byOriginOf(legs...), search, mct)
+	if len(all) != maxItineraryCandidates || itineraryNumbers(all[:2])[1] != "CHEAP" {
+		t.Errorf("buildItineraries() without limit = %d results starting %v", len(all), itineraryNumbers(all[:2]))
+	}
+}
+
+func TestLegFares(t *testing.T) {
+	seats := []models.Seat{
+		{Number: "1A", Class: "business", IsAvailable: true, Price: 500},
+		{Number: "1B", Class: "business", IsAvailable: true, Price: 450},
+		{Number: "10A", Class: "economy", IsAvailable: true, Price: 120},
+		{Number: "10B", Class: "economy", IsAvailable: true, Price: 100},
+		{Number: "10C", Class: "economy", IsAvailable: false, Price: 50},
+		{Number: "10D", Class: "economy", IsAvailable: true, Blocked: true, Price: 50},
+		{Number: "11A", Class: "economy", IsAvailable: true, Price: 110},
+	}
+	flight := models.Flight{Seats: seats}
+
+	tests := []struct {
+		name       string
+		class      string
+		passengers int
+		want       []legFare
+	}{
+		{"any class, one passenger", "", 1, []legFare{{"business", 450, 2}, {"economy", 100, 3}}},
+		{"any class, three passengers drops business", "", 3, []legFare{{"economy", 330, 3}}},
+		{"requested class only", "business", 2, []legFare{{"business", 950, 2}}},
+		{"not enough seats", "economy", 4, nil},
+		{"unknown class", "first", 1, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := legFares(flight, tt.class, tt.passengers); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("legFares() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
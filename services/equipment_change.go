@@ -0,0 +1,336 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"airplane_booking_go/events"
+	"airplane_booking_go/models"
+)
+
+var (
+	ErrFlightChanged = errors.New("flight was modified concurrently")
+	ErrMissingFare   = errors.New("fare is required")
+)
+
+// ApplyFares → salin layout aircraft lalu isi harga tiap cabin dari fares (class → harga)
+func ApplyFares(layout models.CabinLayout, fares map[string]float64) (models.CabinLayout, error) {
+	priced := layout
+	priced.Cabins = make([]models.Cabin, len(layout.Cabins))
+	for i, cabin := range layout.Cabins {
+		price, ok := fares[cabin.Class]
+		if !ok || price <= 0 {
+			return models.CabinLayout{}, fmt.Errorf("%w for class %s", ErrMissingFare, cabin.Class)
+		}
+		cabin.Price = price
+		priced.Cabins[i] = cabin
+	}
+	return priced, nil
+}
+
+// ClassFares → harga per class dari seat map yang ada sekarang
+func ClassFares(seats []models.Seat) map[string]float64 {
+	fares := map[string]float64{}
+	for _, seat := range seats {
+		if _, ok := fares[seat.Class]; !ok {
+			fares[seat.Class] = seat.Price
+		}
+	}
+	return fares
+}
+
+// MinSeatPrice → harga kursi termurah, buat Flight.MinPrice
+func MinSeatPrice(seats []models.Seat) float64 {
+	if len(seats) == 0 {
+		return 0
+	}
+	minPrice := math.MaxFloat64
+	for _, seat := range seats {
+		if seat.Price < minPrice {
+			minPrice = seat.Price
+		}
+	}
+	return minPrice
+}
+
+// ReseatedBooking → kursi baru satu booking setelah ganti pesawat
+type ReseatedBooking struct {
+	Booking       models.Booking     `json:"-"`
+	BookingID     primitive.ObjectID `json:"bookingId"`
+	RecordLocator string             `json:"recordLocator,omitempty"`
+	Seats         []models.Seat      `json:"seats"` // urutan sama dengan kursi lama, Seats[i] milik Passengers[i]
+	Moves         []models.SeatSwap  `json:"moves"` // kursi yang nomornya berubah / hilang
+}
+
+// UnseatedPassenger → penumpang yang gak kebagian kursi setara di pesawat baru, perlu ditangani manual
+type UnseatedPassenger struct {
+	BookingID     primitive.ObjectID `json:"bookingId"`
+	RecordLocator string             `json:"recordLocator,omitempty"`
+	Passenger     string             `json:"passenger"`
+	Class         string             `json:"class"`
+	OldSeat       string             `json:"oldSeat"`
+}
+
+// EquipmentChangePlan → seat map baru + hasil remap penumpang
+type EquipmentChangePlan struct {
+	Seats    []models.Seat       `json:"-"`
+	Bookings []ReseatedBooking   `json:"bookings"`
+	Unseated []UnseatedPassenger `json:"unseated"`
+}
+
+type seatHolder struct {
+	booking int
+	seat    int
+	old     models.Seat
+}
+
+// PlanEquipmentChange → pindahkan kursi booking aktif ke seat map baru.
+// 1. nomor kursi sama, class sama → tetap
+// 2. kursi kosong di class yang sama, prioritas posisi sama (window/aisle/middle), legroom sama, baris terdekat
+// Yang gak dapat kursi tetap ada di booking dengan nomor kursi kosong dan dilaporkan di Unseated.
+func PlanEquipmentChange(flightID primitive.ObjectID, newSeats []models.Seat, bookings []models.Booking) EquipmentChangePlan {
+	seats := make([]models.Seat, len(newSeats))
+	copy(seats, newSeats)
+	index := map[string]int{}
+	for i, seat := range seats {
+		index[seat.Number] = i
+	}
+
+	// booking lama duluan yang dapat prioritas
+	sort.SliceStable(bookings, func(i, j int) bool { return bookings[i].CreatedAt.Before(bookings[j].CreatedAt) })
+
+	plan := EquipmentChangePlan{}
+	var holders []seatHolder
+	for _, booking := range bookings {
+		segment, ok := bookingSegment(booking, flightID)
+		if !ok {
+			continue
+		}
+		reseated := ReseatedBooking{
+			Booking:       booking,
+			BookingID:     booking.ID,
+			RecordLocator: booking.RecordLocator,
+			Seats:         make([]models.Seat, len(segment.Seats)),
+			Moves:         []models.SeatSwap{},
+		}
+		for i, seat := range segment.Seats {
+			holders = append(holders, seatHolder{booking: len(plan.Bookings), seat: i, old: seat})
+		}
+		plan.Bookings = append(plan.Bookings, reseated)
+	}
+
+	take := func(h seatHolder, i int) {
+		seats[i].IsAvailable = false
+		seat := seats[i]
+		seat.Price = h.old.Price // harga yang sudah dibayar gak berubah
+		plan.Bookings[h.booking].Seats[h.seat] = seat
+		if seat.Number != h.old.Number {
+			plan.Bookings[h.booking].Moves = append(plan.Bookings[h.booking].Moves, models.SeatSwap{From: h.old.Number, To: seat.Number})
+		}
+	}
+
+	// 1. nomor kursi yang sama
+	var pending []seatHolder
+	for _, h := range holders {
+		if i, ok := index[h.old.Number]; ok && seats[i].Class == h.old.Class && seats[i].IsBookable() {
+			take(h, i)
+			continue
+		}
+		pending = append(pending, h)
+	}
+
+	// 2. kursi setara di class yang sama
+	for _, h := range pending {
+		best, bestScore := -1, 0
+		for i, seat := range seats {
+			if seat.Class != h.old.Class || !seat.IsBookable() {
+				continue
+			}
+			score := seatDistance(h.old, seat)
+			if best < 0 || score < bestScore {
+				best, bestScore = i, score
+			}
+		}
+		if best >= 0 {
+			take(h, best)
+			continue
+		}
+
+		booking := plan.Bookings[h.booking].Booking
+		plan.Bookings[h.booking].Seats[h.seat] = models.Seat{Class: h.old.Class, Price: h.old.Price}
+		plan.Bookings[h.booking].Moves = append(plan.Bookings[h.booking].Moves, models.SeatSwap{From: h.old.Number})
+		plan.Unseated = append(plan.Unseated, UnseatedPassenger{
+			BookingID:     booking.ID,
+			RecordLocator: booking.RecordLocator,
+			Passenger:     passengerName(booking, h.seat),
+			Class:         h.old.Class,
+			OldSeat:       h.old.Number,
+		})
+	}
+
+	plan.Seats = seats
+	return plan
+}
+
+// seatDistance → makin kecil makin mirip kursi lama
+func seatDistance(old, candidate models.Seat) int {
+	score := 0
+	if old.Position != "" && candidate.Position != old.Position {
+		score += 1000
+	}
+	if candidate.ExtraLegroom != old.ExtraLegroom {
+		score += 500
+	}
+	oldRow, _ := seatCoordinates(old)
+	newRow, _ := seatCoordinates(candidate)
+	if oldRow > newRow {
+		score += oldRow - newRow
+	} else {
+		score += newRow - oldRow
+	}
+	return score
+}
+
+func bookingSegment(booking models.Booking, flightID primitive.ObjectID) (models.BookingSegment, bool) {
+	for _, segment := range booking.AllSegments() {
+		if segment.FlightID == flightID {
+			return segment, true
+		}
+	}
+	return models.BookingSegment{}, false
+}
+
+func passengerName(booking models.Booking, i int) string {
+	if i < len(booking.Passengers) {
+		p := booking.Passengers[i]
+		return strings.TrimSpace(p.FirstName + " " + p.LastName)
+	}
+	return ""
+}
+
+// ApplyEquipmentChange → ganti seat map flight + update kursi semua booking aktif dalam satu transaksi.
+// Gagal dengan ErrFlightChanged kalau flight berubah (ex: ada booking baru) sejak di-load.
+func ApplyEquipmentChange(ctx context.Context, flightColl, bookingColl *mongo.Collection, waitlist *Waitlist, flight models.Flight, aircraftType string, layout models.CabinLayout, plan EquipmentChangePlan, actor string) error {
+	session, err := flightColl.Database().Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		now := time.Now()
+		result, err := flightColl.UpdateOne(sessCtx,
+			bson.M{"_id": flight.ID, "updatedAt": flight.UpdatedAt},
+			bson.M{"$set": bson.M{
+				"aircraftType": aircraftType,
+				"cabinLayout":  layout,
+				"seats":        plan.Seats,
+				"minPrice":     MinSeatPrice(plan.Seats),
+				"updatedAt":    now,
+			}},
+		)
+		if err != nil {
+			return nil, err
+		}
+		if result.ModifiedCount == 0 {
+			return nil, ErrFlightChanged
+		}
+
+		for _, reseated := range plan.Bookings {
+			booking := reseated.Booking
+			set := bson.M{"updatedAt": now}
+			if booking.FlightID == flight.ID {
+				// penumpang ke-i ikut kursi ke-i (segmen pertama)
+				passengers := make([]models.Passenger, len(booking.Passengers))
+				copy(passengers, booking.Passengers)
+				for i := range passengers {
+					if i < len(reseated.Seats) {
+						passengers[i].SeatNumber = reseated.Seats[i].Number
+					}
+				}
+				set["seats"] = reseated.Seats
+				set["passengers"] = passengers
+			}
+			for i, segment := range booking.Segments {
+				if segment.FlightID == flight.ID {
+					set[fmt.Sprintf("segments.%d.seats", i)] = reseated.Seats
+				}
+			}
+
+			update := bson.M{"$set": set}
+			change := models.BookingChange{
+				Type:         "equipment_change",
+				FromFlightID: flight.ID,
+				ToFlightID:   flight.ID,
+				SeatSwaps:    reseated.Moves,
+				Actor:        actor,
+				At:           now,
+			}
+			if len(reseated.Moves) > 0 {
+				update["$push"] = bson.M{"changes": change}
+			}
+
+			res, err := bookingColl.UpdateOne(sessCtx, bson.M{"_id": booking.ID, "status": booking.Status}, update)
+			if err != nil {
+				return nil, err
+			}
+			if res.ModifiedCount == 0 {
+				return nil, ErrBookingChanged
+			}
+			if len(reseated.Moves) > 0 {
+				if err := recordBookingChange(sessCtx, bookingColl.Database(), booking.ID, change); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		err = RecordEvent(sessCtx, flightColl.Database(), events.FlightUpdated, events.AggregateFlight, flight.ID, map[string]interface{}{
+			"changeType":   "equipment_change",
+			"aircraftType": aircraftType,
+			"unseated":     len(plan.Unseated),
+			"actor":        actor,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// kursi yang ditawarkan ke waitlist ikut seat map lama → antrian ditawari ulang setelah commit
+		if waitlist != nil {
+			if err := waitlist.ResetOffers(sessCtx, flight.ID); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	return err
+}
+
+// FindFlightBookings → booking yang masih pegang kursi di flight (single maupun segmen itinerary)
+func FindFlightBookings(ctx context.Context, bookingColl *mongo.Collection, flightID primitive.ObjectID) ([]models.Booking, error) {
+	cursor, err := bookingColl.Find(ctx, bson.M{
+		"status": bson.M{"$in": models.SeatHoldingStatuses},
+		"$or": []bson.M{
+			{"flightId": flightID},
+			{"segments.flightId": flightID},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var bookings []models.Booking
+	if err := cursor.All(ctx, &bookings); err != nil {
+		return nil, err
+	}
+	return bookings, nil
+}
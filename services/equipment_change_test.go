@@ -0,0 +1,167 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"airplane_booking_go/models"
+)
+
+func TestSeatDistance(t *testing.T) {
+	old := models.Seat{Number: "10A", Row: 10, Column: 1, Position: models.SeatPositionWindow}
+
+	tests := []struct {
+		name      string
+		candidate models.Seat
+		want      int
+	}{
+		{"same seat", models.Seat{Row: 10, Column: 1, Position: models.SeatPositionWindow}, 0},
+		{"same position two rows back", models.Seat{Row: 12, Column: 6, Position: models.SeatPositionWindow}, 2},
+		{"same position two rows ahead", models.Seat{Row: 8, Column: 1, Position: models.SeatPositionWindow}, 2},
+		{"different position same row", models.Seat{Row: 10, Column: 3, Position: models.SeatPositionAisle}, 1000},
+		{"legroom mismatch", models.Seat{Row: 11, Column: 1, Position: models.SeatPositionWindow, ExtraLegroom: true}, 501},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := seatDistance(old, tt.candidate); got != tt.want {
+				t.Errorf("seatDistance() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	// seat lama tanpa posisi (seat map lama) → posisi gak dihitung
+	legacy := models.Seat{Number: "E5"}
+	if got := seatDistance(legacy, models.Seat{Number: "5B", Row: 5, Column: 2, Position: models.SeatPositionMiddle}); got != 5 {
+		t.Errorf("seatDistance(legacy) = %d, want 5", got)
+	}
+}
+
+func TestPlanEquipmentChange(t *testing.T) {
+	flightID := primitive.NewObjectID()
+	otherFlightID := primitive.NewObjectID()
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	seat := func(number string, row, col int, position string) models.Seat {
+		return models.Seat{Number: number, Class: "economy", IsAvailable: true, Price: 100, Row: row, Column: col, Position: position}
+	}
+	newSeats := []models.Seat{
+		seat("1A", 1, 1, models.SeatPositionWindow),
+		seat("1B", 1, 2, models.SeatPositionAisle),
+		seat("2A", 2, 1, models.SeatPositionWindow),
+		seat("2B", 2, 2, models.SeatPositionAisle),
+		{Number: "3A", Class: "business", IsAvailable: true, Price: 500, Row: 3, Column: 1, Position: models.SeatPositionWindow},
+	}
+
+	booking := func(locator string, age time.Duration, seats ...models.Seat) models.Booking {
+		return models.Booking{
+			ID:            primitive.NewObjectID(),
+			RecordLocator: locator,
+			FlightID:      flightID,
+			Seats:         seats,
+			CreatedAt:     created.Add(age),
+		}
+	}
+	oldSeat := func(number, position string, row int, price float64) models.Seat {
+		return models.Seat{Number: number, Class: "economy", Price: price, Row: row, Position: position}
+	}
+
+	kept := booking("KEEP01", 0, oldSeat("1A", models.SeatPositionWindow, 1, 80))
+	moved := booking("MOVE01", time.Hour, oldSeat("9A", models.SeatPositionWindow, 9, 90))
+	late := booking("LATE01", 2*time.Hour, oldSeat("9C", models.SeatPositionAisle, 9, 70), oldSeat("9D", models.SeatPositionAisle, 9, 70), oldSeat("9E", models.SeatPositionMiddle, 9, 70))
+	elsewhere := models.Booking{ID: primitive.NewObjectID(), FlightID: otherFlightID, Seats: []models.Seat{oldSeat("1B", models.SeatPositionAisle, 1, 100)}}
+
+	// urutan input sengaja acak, booking yang lebih lama harus diproses duluan
+	plan := PlanEquipmentChange(flightID, newSeats, []models.Booking{late, elsewhere, moved, kept})
+
+	if len(plan.Bookings) != 3 {
+		t.Fatalf("got %d reseated bookings, want 3 (booking on another flight skipped)", len(plan.Bookings))
+	}
+	got := map[string][]string{}
+	moves := map[string][]models.SeatSwap{}
+	for _, b := range plan.Bookings {
+		got[b.RecordLocator] = seatNumbers(b.Seats)
+		moves[b.RecordLocator] = b.Moves
+	}
+
+	want := map[string][]string{
+		"KEEP01": {"1A"},
+		"MOVE01": {"2A"},           // window terdekat yang masih kosong
+		"LATE01": {"2B", "1B", ""}, // aisle terdekat duluan, penumpang ketiga gak kebagian (business beda class)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("seats = %v, want %v", got, want)
+	}
+	if len(moves["KEEP01"]) != 0 {
+		t.Errorf("KEEP01 moves = %v, want none", moves["KEEP01"])
+	}
+	if want := []models.SeatSwap{{From: "9A", To: "2A"}}; !reflect.DeepEqual(moves["MOVE01"], want) {
+		t.Errorf("MOVE01 moves = %v, want %v", moves["MOVE01"], want)
+	}
+	if len(plan.Unseated) != 1 || plan.Unseated[0].RecordLocator != "LATE01" || plan.Unseated[0].OldSeat != "9E" {
+		t.Errorf("Unseated = %+v, want LATE01 seat 9E", plan.Unseated)
+	}
+
+	// harga yang sudah dibayar ikut, bukan harga seat map baru
+	for _, b := range plan.Bookings {
+		if b.RecordLocator == "MOVE01" && b.Seats[0].Price != 90 {
+			t.Errorf("MOVE01 price = %v, want 90", b.Seats[0].Price)
+		}
+	}
+
+	// seat map baru: semua economy terisi, business tetap kosong, input gak diubah
+	for _, s := range plan.Seats {
+		if want := s.Class == "business"; s.IsAvailable != want {
+			t.Errorf("seat %s IsAvailable = %v, want %v", s.Number, s.IsAvailable, want)
+		}
+	}
+	for _, s := range newSeats {
+		if !s.IsAvailable {
+			t.Errorf("input seat %s was modified", s.Number)
+		}
+	}
+}
+
+func TestApplyFares(t *testing.T) {
+	layout := models.CabinLayout{Cabins: []models.Cabin{
+		{Class: "business", FirstRow: 1, LastRow: 2, SeatLetters: "AC"},
+		{Class: "economy", FirstRow: 3, LastRow: 9, SeatLetters: "ABC"},
+	}}
+
+	priced, err := ApplyFares(layout, map[string]float64{"business": 500, "economy": 100})
+	if err != nil {
+		t.Fatalf("ApplyFares() error = %v", err)
+	}
+	if priced.Cabins[0].Price != 500 || priced.Cabins[1].Price != 100 {
+		t.Errorf("ApplyFares() prices = %v / %v, want 500 / 100", priced.Cabins[0].Price, priced.Cabins[1].Price)
+	}
+	if layout.Cabins[0].Price != 0 {
+		t.Error("ApplyFares() modified the input layout")
+	}
+
+	if _, err := ApplyFares(layout, map[string]float64{"economy": 100}); !errors.Is(err, ErrMissingFare) {
+		t.Errorf("ApplyFares() missing class error = %v, want ErrMissingFare", err)
+	}
+	if _, err := ApplyFares(layout, map[string]float64{"business": 0, "economy": 100}); !errors.Is(err, ErrMissingFare) {
+		t.Errorf("ApplyFares() zero fare error = %v, want ErrMissingFare", err)
+	}
+}
+
+func TestMinSeatPrice(t *testing.T) {
+	tests := []struct {
+		seats []models.Seat
+		want  float64
+	}{
+		{nil, 0},
+		{[]models.Seat{{Price: 300}, {Price: 120}, {Price: 450}}, 120},
+	}
+	for _, tt := range tests {
+		if got := MinSeatPrice(tt.seats); got != tt.want {
+			t.Errorf("MinSeatPrice() = %v, want %v", got, tt.want)
+		}
+	}
+}
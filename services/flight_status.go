@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"airplane_booking_go/events"
+	"airplane_booking_go/models"
+)
+
+var (
+	ErrFlightNotBookable       = errors.New("flight is not open for booking")
+	ErrIllegalFlightTransition = errors.New("illegal flight status transition")
+	ErrFlightDeparted          = errors.New("current flight has already departed")
+)
+
+// EnsureBookable → tolak booking / perubahan ke flight yang sudah berangkat atau batal.
+// Jam berangkat yang sudah lewat juga ditolak walau statusnya belum di-update admin.
+func EnsureBookable(flight models.Flight) error {
+	if status := flight.CurrentStatus(); !status.AcceptsBookings() {
+		return fmt.Errorf("%w: %s is %s", ErrFlightNotBookable, flight.FlightNumber, status)
+	}
+	if !flight.DepartureTime.After(time.Now()) {
+		return fmt.Errorf("%w: %s has already departed", ErrFlightNotBookable, flight.FlightNumber)
+	}
+	return nil
+}
+
+// EnsureNotDeparted → flight lama di ganti-flight oleh penumpang: yang sudah jalan gak boleh dipindah
+// (no-show cuma bayar change fee). Re-akomodasi maskapai gak lewat sini.
+func EnsureNotDeparted(flight models.Flight) error {
+	switch flight.CurrentStatus() {
+	case models.FlightStatusDeparted, models.FlightStatusArrived, models.FlightStatusDiverted:
+		return ErrFlightDeparted
+	}
+	if !flight.DepartureTime.After(time.Now()) {
+		return ErrFlightDeparted
+	}
+	return nil
+}
+
+// FlightStatusUpdate → perubahan status operasional + jam estimasi/aktual (nil = gak diubah)
+type FlightStatusUpdate struct {
+	Status                 models.FlightStatus
+	Reason                 string
+	EstimatedDepartureTime *time.Time
+	EstimatedArrivalTime   *time.Time
+	ActualDepartureTime    *time.Time
+	ActualArrivalTime      *time.Time
+}
+
+// UpdateFlightStatus → ubah status flight lewat state machine + catat di statusHistory.
+// Status sama boleh dikirim ulang selama belum final (ex: update estimasi delay).
+func UpdateFlightStatus(ctx context.Context, flightColl *mongo.Collection, flight models.Flight, update FlightStatusUpdate, actor string) error {
+	from := flight.CurrentStatus()
+	sameStatus := from == update.Status && !from.IsFinal()
+	if !sameStatus && !from.CanTransitionTo(update.Status) {
+		return fmt.Errorf("%w: %s → %s", ErrIllegalFlightTransition, from, update.Status)
+	}
+
+	now := time.Now()
+	set := bson.M{"status": update.Status, "updatedAt": now}
+	times := map[string]*time.Time{
+		"estimatedDepartureTime": update.EstimatedDepartureTime,
+		"estimatedArrivalTime":   update.EstimatedArrivalTime,
+		"actualDepartureTime":    update.ActualDepartureTime,
+		"actualArrivalTime":      update.ActualArrivalTime,
+	}
+	// jam aktual otomatis diisi kalau admin gak kirim
+	if update.Status == models.FlightStatusDeparted && update.ActualDepartureTime == nil && flight.ActualDepartureTime == nil {
+		times["actualDepartureTime"] = &now
+	}
+	if (update.Status == models.FlightStatusArrived || update.Status == models.FlightStatusDiverted) &&
+		update.ActualArrivalTime == nil && flight.ActualArrivalTime == nil {
+		times["actualArrivalTime"] = &now
+	}
+	for key, t := range times {
+		if t != nil {
+			set[key] = t.UTC()
+		}
+	}
+
+	filter := bson.M{"_id": flight.ID, "status": flight.Status}
+	if flight.Status == "" {
+		filter["status"] = bson.M{"$exists": false}
+	}
+
+	eventType := events.FlightStatusChanged
+	if update.Status == models.FlightStatusCancelled {
+		eventType = events.FlightCancelled
+	}
+	data := map[string]interface{}{
+		"from":   string(from),
+		"to":     string(update.Status),
+		"actor":  actor,
+		"reason": update.Reason,
+	}
+	if update.EstimatedDepartureTime != nil {
+		data["estimatedDepartureTime"] = update.EstimatedDepartureTime.UTC()
+	}
+
+	return InTransaction(ctx, flightColl.Database().Client(), func(ctx context.Context) error {
+		result, err := flightColl.UpdateOne(ctx, filter, bson.M{
+			"$set": set,
+			"$push": bson.M{"statusHistory": models.FlightStatusChange{
+				From:   from,
+				To:     update.Status,
+				Actor:  actor,
+				Reason: update.Reason,
+				At:     now,
+			}},
+		})
+		if err != nil {
+			return err
+		}
+		if result.ModifiedCount == 0 {
+			return ErrFlightChanged
+		}
+		return RecordEvent(ctx, flightColl.Database(), eventType, events.AggregateFlight, flight.ID, data)
+	})
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"airplane_booking_go/models"
+)
+
+func TestEnsureBookable(t *testing.T) {
+	future := time.Now().Add(24 * time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		status  models.FlightStatus
+		depart  time.Time
+		wantErr bool
+	}{
+		{"legacy flight without status", "", future, false},
+		{"scheduled", models.FlightStatusScheduled, future, false},
+		{"delayed", models.FlightStatusDelayed, future, false},
+		{"boarding", models.FlightStatusBoarding, future, false},
+		{"departed", models.FlightStatusDeparted, future, true},
+		{"arrived", models.FlightStatusArrived, future, true},
+		{"cancelled", models.FlightStatusCancelled, future, true},
+		{"diverted", models.FlightStatusDiverted, future, true},
+		{"scheduled but departure time passed", models.FlightStatusScheduled, past, true},
+		{"legacy flight with departure time passed", "", past, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := EnsureBookable(models.Flight{FlightNumber: "GA100", Status: tt.status, DepartureTime: tt.depart})
+			if tt.wantErr && !errors.Is(err, ErrFlightNotBookable) {
+				t.Fatalf("EnsureBookable() error = %v, want ErrFlightNotBookable", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("EnsureBookable() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestEnsureNotDeparted(t *testing.T) {
+	future := time.Now().Add(24 * time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		status  models.FlightStatus
+		depart  time.Time
+		wantErr bool
+	}{
+		{"scheduled", models.FlightStatusScheduled, future, false},
+		{"delayed", models.FlightStatusDelayed, future, false},
+		{"cancelled before departure", models.FlightStatusCancelled, future, false},
+		{"departed", models.FlightStatusDeparted, future, true},
+		{"arrived", models.FlightStatusArrived, past, true},
+		{"diverted", models.FlightStatusDiverted, past, true},
+		{"scheduled but departure time passed", models.FlightStatusScheduled, past, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := EnsureNotDeparted(models.Flight{Status: tt.status, DepartureTime: tt.depart})
+			if tt.wantErr && !errors.Is(err, ErrFlightDeparted) {
+				t.Fatalf("EnsureNotDeparted() error = %v, want ErrFlightDeparted", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("EnsureNotDeparted() error = %v", err)
+			}
+		})
+	}
+}
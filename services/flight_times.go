@@ -0,0 +1,58 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"airplane_booking_go/models"
+)
+
+var ErrInvalidFlightTime = errors.New("invalid flight time")
+
+// format jam lokal tanpa offset, dibaca di zona waktu bandara
+var localTimeLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02 15:04"}
+
+// ParseAirportTime → waktu dari client. RFC3339 (ada offset) dipakai apa adanya,
+// jam lokal tanpa offset ("2006-01-02T15:04") dibaca di zona waktu bandara. Hasilnya UTC.
+func ParseAirportTime(value string, airport models.Airport) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+
+	loc := airport.Location()
+	for _, layout := range localTimeLayouts {
+		t, err := time.ParseInLocation(layout, value, time.UTC)
+		if err != nil {
+			continue
+		}
+		if loc == nil {
+			return time.Time{}, fmt.Errorf("%w: %q has no offset and airport %s has no timeZone", ErrInvalidFlightTime, value, airport.Code)
+		}
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc)
+		return t.UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: %q, use RFC3339 or local time 2006-01-02T15:04", ErrInvalidFlightTime, value)
+}
+
+// FlightTimes → jam berangkat/tiba dalam UTC + durasi (menit) dihitung dari selisihnya
+func FlightTimes(departure, arrival models.Airport, departureTime, arrivalTime string) (time.Time, time.Time, int, error) {
+	for _, airport := range []models.Airport{departure, arrival} {
+		if err := ValidateAirport(airport); err != nil {
+			return time.Time{}, time.Time{}, 0, err
+		}
+	}
+
+	dep, err := ParseAirportTime(departureTime, departure)
+	if err != nil {
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("departureTime: %w", err)
+	}
+	arr, err := ParseAirportTime(arrivalTime, arrival)
+	if err != nil {
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("arrivalTime: %w", err)
+	}
+	if !arr.After(dep) {
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("%w: arrivalTime must be after departureTime", ErrInvalidFlightTime)
+	}
+	return dep, arr, int(arr.Sub(dep) / time.Minute), nil
+}
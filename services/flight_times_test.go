@@ -0,0 +1,96 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"airplane_booking_go/models"
+)
+
+var (
+	cgk = models.Airport{Code: "CGK", TimeZone: "Asia/Jakarta"}
+	dps = models.Airport{Code: "DPS", TimeZone: "Asia/Makassar"}
+	nrt = models.Airport{Code: "NRT", TimeZone: "Asia/Tokyo"}
+	hnl = models.Airport{Code: "HNL", TimeZone: "Pacific/Honolulu"}
+	xxx = models.Airport{Code: "XXX"} // data lama tanpa timeZone
+)
+
+func TestParseAirportTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		airport models.Airport
+		want    string
+		wantErr bool
+	}{
+		{"rfc3339 kept as is", "2026-05-01T08:00:00+07:00", dps, "2026-05-01T01:00:00Z", false},
+		{"rfc3339 without airport time zone", "2026-05-01T08:00:00Z", xxx, "2026-05-01T08:00:00Z", false},
+		{"local minutes", "2026-05-01T08:00", cgk, "2026-05-01T01:00:00Z", false},
+		{"local seconds", "2026-05-01T08:00:30", dps, "2026-05-01T00:00:30Z", false},
+		{"local with space", "2026-05-01 23:15", nrt, "2026-05-01T14:15:00Z", false},
+		{"local without airport time zone", "2026-05-01T08:00", xxx, "", true},
+		{"garbage", "tomorrow morning", cgk, "", true},
+		{"date only", "2026-05-01", cgk, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAirportTime(tt.value, tt.airport)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidFlightTime) {
+					t.Fatalf("ParseAirportTime(%q) = %v, %v; want ErrInvalidFlightTime", tt.value, got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseAirportTime(%q) error = %v", tt.value, err)
+			}
+			if got.Location() != time.UTC {
+				t.Errorf("ParseAirportTime(%q) location = %v, want UTC", tt.value, got.Location())
+			}
+			if got.Format(time.RFC3339) != tt.want {
+				t.Errorf("ParseAirportTime(%q) = %s, want %s", tt.value, got.Format(time.RFC3339), tt.want)
+			}
+		})
+	}
+}
+
+func TestFlightTimes(t *testing.T) {
+	tests := []struct {
+		name         string
+		from, to     models.Airport
+		dep, arr     string
+		wantDuration int
+		wantErr      bool
+	}{
+		{"local times in different zones", cgk, dps, "2026-05-01T08:00", "2026-05-01T10:50", 110, false},
+		{"arrival local clock earlier than departure", nrt, hnl, "2026-05-01T17:00", "2026-05-01T05:30", 450, false},
+		{"mixed rfc3339 and local", cgk, dps, "2026-05-01T01:00:00Z", "2026-05-01T10:50", 110, false},
+		{"arrival before departure", cgk, dps, "2026-05-01T10:00", "2026-05-01T10:30", 0, true},
+		{"arrival equal to departure", cgk, cgk, "2026-05-01T10:00", "2026-05-01T10:00", 0, true},
+		{"unknown airport time zone", models.Airport{Code: "BAD", TimeZone: "Nowhere/City"}, dps, "2026-05-01T08:00:00Z", "2026-05-01T10:50", 0, true},
+		{"bad arrival", cgk, dps, "2026-05-01T08:00", "soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dep, arr, duration, err := FlightTimes(tt.from, tt.to, tt.dep, tt.arr)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("FlightTimes() = %v, %v, %d; want error", dep, arr, duration)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FlightTimes() error = %v", err)
+			}
+			if duration != tt.wantDuration {
+				t.Errorf("FlightTimes() duration = %d, want %d", duration, tt.wantDuration)
+			}
+			if got := int(arr.Sub(dep) / time.Minute); got != duration {
+				t.Errorf("FlightTimes() arr - dep = %d minutes, duration = %d", got, duration)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"airplane_booking_go/models"
+)
+
+// HoldSweeper → background job yang melepas kursi dari hold yang sudah expired
+type HoldSweeper struct {
+	BookingCollection *mongo.Collection
+	FlightCollection  *mongo.Collection
+	Waitlist          *Waitlist
+	Interval          time.Duration
+}
+
+func NewHoldSweeper(bookingColl, flightColl *mongo.Collection, waitlist *Waitlist, interval time.Duration) *HoldSweeper {
+	return &HoldSweeper{
+		BookingCollection: bookingColl,
+		FlightCollection:  flightColl,
+		Waitlist:          waitlist,
+		Interval:          interval,
+	}
+}
+
+// Start → jalankan sweeper sampai ctx di-cancel
+func (s *HoldSweeper) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if n, err := s.Sweep(ctx); err != nil {
+					log.Println("hold sweeper:", err)
+				} else if n > 0 {
+					log.Printf("hold sweeper: released %d expired hold(s)\n", n)
+				}
+				if n, err := s.Waitlist.ExpireOffers(ctx); err != nil {
+					log.Println("hold sweeper: waitlist:", err)
+				} else if n > 0 {
+					log.Printf("hold sweeper: expired %d waitlist offer(s)\n", n)
+				}
+			}
+		}
+	}()
+}
+
+// Sweep → expire semua hold yang lewat waktu, return jumlah booking yang di-expire
+func (s *HoldSweeper) Sweep(ctx context.Context) (int, error) {
+	findCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := s.BookingCollection.Find(findCtx, bson.M{
+		"status":        bson.M{"$in": []models.BookingStatus{models.BookingStatusHeld, models.BookingStatusPending}},
+		"holdExpiresAt": bson.M{"$lte": time.Now()},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var bookings []models.Booking
+	if err := cursor.All(findCtx, &bookings); err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, booking := range bookings {
+		ok, err := s.expireHold(ctx, booking)
+		if err != nil {
+			log.Printf("hold sweeper: booking %s: %v\n", booking.ID.Hex(), err)
+			continue
+		}
+		if ok {
+			released++
+			// kursi kebuka → tawarkan ke waitlist
+			s.Waitlist.OfferFreedSeatsForBooking(ctx, booking)
+		}
+	}
+	return released, nil
+}
+
+func (s *HoldSweeper) expireHold(ctx context.Context, booking models.Booking) (bool, error) {
+	txCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	session, err := s.BookingCollection.Database().Client().StartSession()
+	if err != nil {
+		return false, err
+	}
+	defer session.EndSession(txCtx)
+
+	result, err := session.WithTransaction(txCtx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		// pastikan booking masih nunggu bayar, bisa aja barusan dibayar/cancel
+		err := TransitionBooking(sessCtx, s.BookingCollection, booking.ID, booking.Status, Transition{
+			To:     models.BookingStatusExpired,
+			Actor:  ActorSystem,
+			Reason: "hold expired",
+			Filter: bson.M{"holdExpiresAt": bson.M{"$lte": time.Now()}},
+		})
+		if errors.Is(err, ErrBookingChanged) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		if err := ReleaseBookingSeats(sessCtx, s.FlightCollection, booking); err != nil {
+			return false, err
+		}
+		return true, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.(bool), nil
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"airplane_booking_go/models"
+)
+
+// ValidateItinerary → flight harus nyambung: tujuan leg sebelumnya = asal leg berikutnya,
+// dan leg berikutnya berangkat setelah leg sebelumnya mendarat + waktu transit minimum bandaranya
+func ValidateItinerary(flights []models.Flight, minConnection func(string) time.Duration) error {
+	seen := map[string]bool{}
+	for i, flight := range flights {
+		if seen[flight.ID.Hex()] {
+			return fmt.Errorf("flight %s appears more than once", flight.FlightNumber)
+		}
+		seen[flight.ID.Hex()] = true
+		if err := EnsureBookable(flight); err != nil {
+			return err
+		}
+
+		if i == 0 {
+			continue
+		}
+		prev := flights[i-1]
+		if prev.Arrival.Code != flight.Departure.Code {
+			return fmt.Errorf("segment %d departs from %s but segment %d arrives at %s", i+1, flight.Departure.Code, i, prev.Arrival.Code)
+		}
+		if !flight.DepartureTime.After(prev.ArrivalTime) {
+			return fmt.Errorf("segment %d departs before segment %d arrives", i+1, i)
+		}
+		if mct := minConnection(flight.Departure.Code); flight.DepartureTime.Before(prev.ArrivalTime.Add(mct)) {
+			return fmt.Errorf("segment %d departs less than %d minutes after segment %d arrives (minimum connection time at %s)",
+				i+1, int(mct/time.Minute), i, flight.Departure.Code)
+		}
+	}
+	return nil
+}
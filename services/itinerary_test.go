@@ -0,0 +1,62 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"airplane_booking_go/models"
+)
+
+func TestValidateItinerary(t *testing.T) {
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	flight := func(number, from, to string, dep, arr time.Duration) models.Flight {
+		return models.Flight{
+			ID:            primitive.NewObjectID(),
+			FlightNumber:  number,
+			Departure:     models.Airport{Code: from},
+			Arrival:       models.Airport{Code: to},
+			DepartureTime: start.Add(dep),
+			ArrivalTime:   start.Add(arr),
+		}
+	}
+	mct := func(code string) time.Duration {
+		if code == "SUB" {
+			return 90 * time.Minute
+		}
+		return 45 * time.Minute
+	}
+
+	first := flight("GA1", "CGK", "SUB", 0, time.Hour)
+	cancelled := flight("GA9", "SUB", "DPS", 3*time.Hour, 4*time.Hour)
+	cancelled.Status = models.FlightStatusCancelled
+
+	tests := []struct {
+		name    string
+		flights []models.Flight
+		wantErr bool
+	}{
+		{"single flight", []models.Flight{first}, false},
+		{"connection above minimum", []models.Flight{first, flight("GA2", "SUB", "DPS", 3*time.Hour, 4*time.Hour)}, false},
+		{"connection exactly at minimum", []models.Flight{first, flight("GA2", "SUB", "DPS", 150*time.Minute, 4*time.Hour)}, false}, // tiba 1 jam + 90 menit MCT di SUB
+		{"connection below airport minimum", []models.Flight{first, flight("GA2", "SUB", "DPS", 80*time.Minute, 3*time.Hour)}, true},
+		{"round trip after a long stay", []models.Flight{first, flight("GA2", "SUB", "CGK", 72*time.Hour, 73*time.Hour)}, false},
+		{"departs before previous arrival", []models.Flight{first, flight("GA2", "SUB", "DPS", 30*time.Minute, 2*time.Hour)}, true},
+		{"airports do not connect", []models.Flight{first, flight("GA2", "UPG", "DPS", 5*time.Hour, 6*time.Hour)}, true},
+		{"same flight twice", []models.Flight{first, first}, true},
+		{"cancelled segment", []models.Flight{first, cancelled}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateItinerary(tt.flights, mct)
+			if tt.wantErr && err == nil {
+				t.Fatal("ValidateItinerary() error = nil, want error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("ValidateItinerary() error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,426 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"airplane_booking_go/events"
+	"airplane_booking_go/models"
+	"airplane_booking_go/notifications"
+)
+
+const (
+	notificationLease       = 5 * time.Minute // notifikasi yang lagi dikirim gak diambil worker lain selama ini
+	notificationBaseBackoff = 30 * time.Second
+	notificationMaxBackoff  = time.Hour
+)
+
+// NotificationService → consumer outbox yang mengubah domain event jadi notifikasi di antrian persisten.
+// Pengiriman + retry jalan di background, jadi gagal kirim gak pernah gagalin booking.
+type NotificationService struct {
+	NotificationCollection *mongo.Collection
+	UserCollection         *mongo.Collection
+	BookingCollection      *mongo.Collection
+	FlightCollection       *mongo.Collection
+	Currency               string
+	Notifiers              map[string]notifications.Notifier
+	Templates              *notifications.Templates
+	MaxAttempts            int
+	Interval               time.Duration
+}
+
+func NewNotificationService(notificationColl, userColl, bookingColl, flightColl *mongo.Collection, currency string, notifiers []notifications.Notifier, templates *notifications.Templates, maxAttempts int, interval time.Duration) *NotificationService {
+	byChannel := map[string]notifications.Notifier{}
+	for _, notifier := range notifiers {
+		byChannel[notifier.Channel()] = notifier
+	}
+	return &NotificationService{
+		NotificationCollection: notificationColl,
+		UserCollection:         userColl,
+		BookingCollection:      bookingColl,
+		FlightCollection:       flightColl,
+		Currency:               currency,
+		Notifiers:              byChannel,
+		Templates:              templates,
+		MaxAttempts:            maxAttempts,
+		Interval:               interval,
+	}
+}
+
+// HandleEvent → consumer outbox: event booking / flight yang relevan buat penumpang masuk antrian notifikasi
+func (s *NotificationService) HandleEvent(ctx context.Context, event events.Event) error {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	switch event.Type {
+	case events.BookingConfirmed:
+		booking, flight, err := s.loadBooking(ctx, event.AggregateID)
+		if err != nil {
+			return err
+		}
+		return s.enqueue(ctx, event.ID, notifications.EventBookingConfirmed, booking, BookingNotificationData(booking, flight, s.Currency))
+
+	case events.BookingCancelled:
+		booking, flight, err := s.loadBooking(ctx, event.AggregateID)
+		if err != nil {
+			return err
+		}
+		data := BookingNotificationData(booking, flight, s.Currency)
+		if booking.RefundAmount > 0 {
+			data["Refunded"] = fmt.Sprintf("%.2f", booking.RefundAmount)
+		}
+		// alasan cuma ditampilkan kalau yang cancel bukan penumpangnya sendiri (admin / maskapai).
+		// Selalu diisi biar template override gak nge-print "<no value>"
+		data["Reason"] = ""
+		if reason := eventString(event, "reason"); reason != "" && eventString(event, "actor") != booking.UserID.Hex() {
+			data["Reason"] = reason
+		}
+		return s.enqueue(ctx, event.ID, notifications.EventBookingCancelled, booking, data)
+
+	case events.BookingUpdated:
+		if eventString(event, "changeType") != "reaccommodation" {
+			return nil
+		}
+		booking, newFlight, err := s.loadBooking(ctx, event.AggregateID)
+		if err != nil {
+			return err
+		}
+		cancelled, err := s.loadFlight(ctx, eventString(event, "fromFlightId"))
+		if err != nil {
+			return err
+		}
+		data := BookingNotificationData(booking, cancelled, s.Currency)
+		data["NewFlightNumber"] = newFlight.FlightNumber
+		data["NewDepartureTime"] = notificationTime(newFlight.Departure, newFlight.DepartureTime)
+		return s.enqueue(ctx, event.ID, notifications.EventBookingRebooked, booking, data)
+
+	case events.FlightStatusChanged:
+		// status yang ngaruh ke rencana penumpang aja
+		switch models.FlightStatus(eventString(event, "to")) {
+		case models.FlightStatusDelayed, models.FlightStatusBoarding, models.FlightStatusDiverted:
+		default:
+			return nil
+		}
+		flight, err := s.loadFlight(ctx, event.AggregateID)
+		if err != nil {
+			return err
+		}
+		bookings, err := FindFlightBookings(ctx, s.BookingCollection, flight.ID)
+		if err != nil {
+			return err
+		}
+		return s.notifyFlightStatus(ctx, event, bookings, flight)
+
+	case events.FlightUpdated:
+		// perubahan jadwal / pesawat ngaruh ke rencana penumpang, edit lain (harga, ...) gak dikabari
+		var notification string
+		switch eventString(event, "changeType") {
+		case "schedule_change":
+			notification = notifications.EventFlightRescheduled
+		case "equipment_change":
+			notification = notifications.EventFlightReseated
+		default:
+			return nil
+		}
+		flight, err := s.loadFlight(ctx, event.AggregateID)
+		if err != nil {
+			return err
+		}
+		bookings, err := FindFlightBookings(ctx, s.BookingCollection, flight.ID)
+		if err != nil {
+			return err
+		}
+		for _, booking := range bookings {
+			data := flightNotificationData(booking, flight, s.Currency)
+			if notification == notifications.EventFlightRescheduled {
+				data["PreviousFrom"] = eventString(event, "previousDeparture")
+				data["PreviousTo"] = eventString(event, "previousArrival")
+				data["PreviousDepartureTime"] = eventTime(event, "previousDepartureTime", "previousDepartureTimeZone")
+				data["PreviousArrivalTime"] = eventTime(event, "previousArrivalTime", "previousArrivalTimeZone")
+			}
+			if err := s.enqueue(ctx, event.ID, notification, booking, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// eventTime → waktu RFC3339 di data event, diformat di zona waktu (IANA) dari zoneKey
+func eventTime(event events.Event, key, zoneKey string) string {
+	t, err := time.Parse(time.RFC3339, eventString(event, key))
+	if err != nil {
+		return ""
+	}
+	return notificationTime(models.Airport{TimeZone: eventString(event, zoneKey)}, t)
+}
+
+// notifyFlightStatus → kabari semua pemegang booking di flight ini soal perubahan status
+func (s *NotificationService) notifyFlightStatus(ctx context.Context, event events.Event, bookings []models.Booking, flight models.Flight) error {
+	estimated := ""
+	if flight.EstimatedDepartureTime != nil {
+		estimated = notificationTime(flight.Departure, *flight.EstimatedDepartureTime)
+	}
+	for _, booking := range bookings {
+		data := flightNotificationData(booking, flight, s.Currency)
+		data["Status"] = eventString(event, "to")
+		data["EstimatedDepartureTime"] = estimated
+		data["Reason"] = eventString(event, "reason")
+		if err := s.enqueue(ctx, event.ID, notifications.EventFlightStatusChanged, booking, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *NotificationService) loadBooking(ctx context.Context, id string) (models.Booking, models.Flight, error) {
+	bookingID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return models.Booking{}, models.Flight{}, err
+	}
+	var booking models.Booking
+	if err := s.BookingCollection.FindOne(ctx, bson.M{"_id": bookingID}).Decode(&booking); err != nil {
+		return models.Booking{}, models.Flight{}, fmt.Errorf("fetch booking %s: %v", id, err)
+	}
+	flight, err := s.loadFlight(ctx, booking.FlightID.Hex())
+	return booking, flight, err
+}
+
+func (s *NotificationService) loadFlight(ctx context.Context, id string) (models.Flight, error) {
+	flightID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return models.Flight{}, err
+	}
+	var flight models.Flight
+	if err := s.FlightCollection.FindOne(ctx, bson.M{"_id": flightID}).Decode(&flight); err != nil {
+		return models.Flight{}, fmt.Errorf("fetch flight %s: %v", id, err)
+	}
+	return flight, nil
+}
+
+// enqueue → render template lalu masukkan ke antrian untuk setiap channel aktif.
+// Satu notifikasi per event + booking + channel (unique index), jadi event yang dikirim ulang relay
+// gak dobel, tapi user dengan dua booking di flight yang sama tetap dapat dua.
+func (s *NotificationService) enqueue(ctx context.Context, eventID, event string, booking models.Booking, data map[string]interface{}) error {
+	var user models.User
+	if err := s.UserCollection.FindOne(ctx, bson.M{"_id": booking.UserID}).Decode(&user); err != nil {
+		return fmt.Errorf("fetch user: %v", err)
+	}
+	if _, ok := data["Name"]; !ok {
+		data["Name"] = user.Name
+	}
+
+	subject, body, err := s.Templates.Render(event, user.Locale, data)
+	if err != nil {
+		return err
+	}
+	locale := user.Locale
+	if locale == "" {
+		locale = s.Templates.DefaultLocale
+	}
+
+	now := time.Now()
+	docs := make([]interface{}, 0, len(s.Notifiers))
+	for channel := range s.Notifiers {
+		docs = append(docs, models.Notification{
+			ID:            primitive.NewObjectID(),
+			EventID:       eventID,
+			Event:         event,
+			Channel:       channel,
+			UserID:        booking.UserID,
+			BookingID:     booking.ID,
+			To:            user.Email,
+			Locale:        locale,
+			Subject:       subject,
+			Body:          body,
+			Status:        "pending",
+			NextAttemptAt: now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+	_, err = s.NotificationCollection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if mongo.IsDuplicateKeyError(err) {
+		// sebagian / semua sudah masuk di percobaan sebelumnya
+		return nil
+	}
+	return err
+}
+
+// Start → proses antrian tiap Interval sampai ctx di-cancel
+func (s *NotificationService) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				sent, failed, err := s.ProcessDue(ctx)
+				if err != nil {
+					log.Println("notification worker:", err)
+				}
+				if sent > 0 || failed > 0 {
+					log.Printf("notification worker: sent %d, failed %d\n", sent, failed)
+				}
+			}
+		}
+	}()
+}
+
+// ProcessDue → kirim semua notifikasi yang sudah waktunya, return jumlah terkirim dan gagal (percobaan ini)
+func (s *NotificationService) ProcessDue(ctx context.Context) (int, int, error) {
+	sent, failed := 0, 0
+	for {
+		notification, err := s.claimNext(ctx)
+		if err == mongo.ErrNoDocuments {
+			return sent, failed, nil
+		}
+		if err != nil {
+			return sent, failed, err
+		}
+
+		if err := s.send(ctx, notification); err != nil {
+			failed++
+			s.markFailed(ctx, notification, err)
+			continue
+		}
+		sent++
+		s.markSent(ctx, notification)
+	}
+}
+
+// claimNext → ambil satu notifikasi pending yang jatuh tempo, dikunci pakai lease biar gak dobel kirim
+func (s *NotificationService) claimNext(ctx context.Context) (models.Notification, error) {
+	now := time.Now()
+	var notification models.Notification
+	err := s.NotificationCollection.FindOneAndUpdate(ctx,
+		bson.M{"status": "pending", "nextAttemptAt": bson.M{"$lte": now}},
+		bson.M{
+			"$set": bson.M{"nextAttemptAt": now.Add(notificationLease), "updatedAt": now},
+			"$inc": bson.M{"attempts": 1},
+		},
+		options.FindOneAndUpdate().
+			SetSort(bson.D{{Key: "nextAttemptAt", Value: 1}}).
+			SetReturnDocument(options.After),
+	).Decode(&notification)
+	return notification, err
+}
+
+func (s *NotificationService) send(ctx context.Context, notification models.Notification) error {
+	notifier, ok := s.Notifiers[notification.Channel]
+	if !ok {
+		return fmt.Errorf("channel %s is not configured", notification.Channel)
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	return notifier.Send(sendCtx, notifications.Message{
+		To:      notification.To,
+		Subject: notification.Subject,
+		Body:    notification.Body,
+		Event:   notification.Event,
+		Locale:  notification.Locale,
+	})
+}
+
+func (s *NotificationService) markSent(ctx context.Context, notification models.Notification) {
+	now := time.Now()
+	_, err := s.NotificationCollection.UpdateOne(ctx,
+		bson.M{"_id": notification.ID},
+		bson.M{
+			"$set":   bson.M{"status": "sent", "sentAt": now, "updatedAt": now},
+			"$unset": bson.M{"lastError": ""},
+		},
+	)
+	if err != nil {
+		log.Printf("notification: mark %s sent: %v\n", notification.ID.Hex(), err)
+	}
+}
+
+// markFailed → jadwalkan ulang dengan exponential backoff, atau gagal permanen setelah MaxAttempts
+func (s *NotificationService) markFailed(ctx context.Context, notification models.Notification, sendErr error) {
+	set := bson.M{"lastError": sendErr.Error(), "updatedAt": time.Now()}
+	if notification.Attempts >= s.MaxAttempts {
+		set["status"] = "failed"
+	} else {
+		set["nextAttemptAt"] = time.Now().Add(Backoff(notification.Attempts, notificationBaseBackoff, notificationMaxBackoff))
+	}
+
+	if _, err := s.NotificationCollection.UpdateOne(ctx, bson.M{"_id": notification.ID}, bson.M{"$set": set}); err != nil {
+		log.Printf("notification: mark %s failed: %v\n", notification.ID.Hex(), err)
+	}
+}
+
+// Backoff → base * 2^(attempt-1), maksimal max
+func Backoff(attempt int, base, max time.Duration) time.Duration {
+	d := base
+	for i := 1; i < attempt && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		return max
+	}
+	return d
+}
+
+// BookingNotificationData → data template standar untuk notifikasi booking
+func BookingNotificationData(booking models.Booking, flight models.Flight, currency string) map[string]interface{} {
+	seats := make([]string, 0, len(booking.Seats))
+	for _, seat := range booking.Seats {
+		if seat.Number != "" {
+			seats = append(seats, seat.Number)
+		}
+	}
+	data := map[string]interface{}{
+		"RecordLocator": booking.RecordLocator,
+		"FlightNumber":  flight.FlightNumber,
+		"From":          flight.Departure.Code,
+		"To":            flight.Arrival.Code,
+		"DepartureTime": notificationTime(flight.Departure, flight.DepartureTime),
+		"ArrivalTime":   notificationTime(flight.Arrival, flight.ArrivalTime),
+		"Seats":         strings.Join(seats, ", "),
+		"TotalPrice":    fmt.Sprintf("%.2f", booking.TotalPrice),
+		"Currency":      currency,
+	}
+	return data
+}
+
+// flightNotificationData → BookingNotificationData dengan kursi dari segmen flight ini (booking itinerary)
+func flightNotificationData(booking models.Booking, flight models.Flight, currency string) map[string]interface{} {
+	data := BookingNotificationData(booking, flight, currency)
+	if segment, ok := bookingSegment(booking, flight.ID); ok {
+		seats := make([]string, 0, len(segment.Seats))
+		for _, seat := range segment.Seats {
+			if seat.Number != "" {
+				seats = append(seats, seat.Number)
+			}
+		}
+		data["Seats"] = strings.Join(seats, ", ")
+	}
+	return data
+}
+
+// notificationTime → jam lokal bandara (sama seperti departureLocalTime di response API), UTC kalau
+// zona waktu bandaranya belum diisi
+func notificationTime(airport models.Airport, t time.Time) string {
+	if loc := airport.Location(); loc != nil {
+		return t.In(loc).Format("2006-01-02 15:04 MST")
+	}
+	return t.UTC().Format("2006-01-02 15:04 MST")
+}
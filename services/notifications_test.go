@@ -0,0 +1,69 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"airplane_booking_go/models"
+)
+
+func TestBackoff(t *testing.T) {
+	base, max := 30*time.Second, 10*time.Minute
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, 30 * time.Second},
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{5, 8 * time.Minute},
+		{6, 10 * time.Minute},
+		{50, 10 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := Backoff(tt.attempt, base, max); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestNotificationTime(t *testing.T) {
+	at := time.Date(2026, 5, 1, 1, 0, 0, 0, time.UTC)
+	tests := []struct {
+		airport models.Airport
+		want    string
+	}{
+		{models.Airport{Code: "CGK", TimeZone: "Asia/Jakarta"}, "2026-05-01 08:00 WIB"},
+		{models.Airport{Code: "DPS", TimeZone: "Asia/Makassar"}, "2026-05-01 09:00 WITA"},
+		{models.Airport{Code: "XXX"}, "2026-05-01 01:00 UTC"},
+	}
+	for _, tt := range tests {
+		if got := notificationTime(tt.airport, at); got != tt.want {
+			t.Errorf("notificationTime(%s) = %q, want %q", tt.airport.Code, got, tt.want)
+		}
+	}
+}
+
+func TestFlightNotificationDataUsesSegmentSeats(t *testing.T) {
+	outbound, inbound := primitive.NewObjectID(), primitive.NewObjectID()
+	booking := models.Booking{
+		RecordLocator: "ABC123",
+		FlightID:      outbound,
+		Seats:         []models.Seat{{Number: "1A"}, {Number: "1B"}},
+		Segments: []models.BookingSegment{
+			{FlightID: outbound, Seats: []models.Seat{{Number: "1A"}, {Number: "1B"}}},
+			{FlightID: inbound, Seats: []models.Seat{{Number: "20C"}, {Number: ""}}},
+		},
+	}
+
+	data := flightNotificationData(booking, models.Flight{ID: inbound, FlightNumber: "GA401"}, "IDR")
+	if data["Seats"] != "20C" {
+		t.Errorf("Seats = %q, want %q", data["Seats"], "20C")
+	}
+	if data["FlightNumber"] != "GA401" || data["RecordLocator"] != "ABC123" {
+		t.Errorf("data = %v, want flight GA401 booking ABC123", data)
+	}
+}
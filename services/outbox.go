@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"airplane_booking_go/events"
+	"airplane_booking_go/models"
+)
+
+// OutboxCollection → nama collection outbox, satu database dengan booking/flights biar bisa satu transaksi
+const OutboxCollection = "outbox"
+
+const (
+	outboxLease       = time.Minute
+	outboxBaseBackoff = 5 * time.Second
+	outboxMaxBackoff  = 10 * time.Minute
+)
+
+// RecordEvent → tulis domain event ke outbox. Panggil dengan ctx transaksi (sessCtx)
+// supaya event cuma ada kalau perubahannya ikut ter-commit.
+func RecordEvent(ctx context.Context, db *mongo.Database, eventType, aggregateType string, aggregateID primitive.ObjectID, data map[string]interface{}) error {
+	now := time.Now()
+	_, err := db.Collection(OutboxCollection).InsertOne(ctx, models.OutboxEvent{
+		ID:            primitive.NewObjectID(),
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Data:          data,
+		Status:        "pending",
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	})
+	return err
+}
+
+// InTransaction → jalankan fn dalam transaksi. Kalau ctx sudah bawa session (dipanggil dari
+// dalam transaksi lain), fn langsung jalan di transaksi itu.
+func InTransaction(ctx context.Context, client *mongo.Client, fn func(ctx context.Context) error) error {
+	if mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	session, err := client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	})
+	return err
+}
+
+// OutboxRelay → publish event outbox yang sudah ter-commit ke Publisher (bus / broker).
+// At-least-once: consumer harus dedup pakai Event.ID.
+type OutboxRelay struct {
+	Collection  *mongo.Collection
+	Publisher   events.Publisher
+	MaxAttempts int
+	Interval    time.Duration
+}
+
+func NewOutboxRelay(outboxColl *mongo.Collection, publisher events.Publisher, maxAttempts int, interval time.Duration) *OutboxRelay {
+	return &OutboxRelay{
+		Collection:  outboxColl,
+		Publisher:   publisher,
+		MaxAttempts: maxAttempts,
+		Interval:    interval,
+	}
+}
+
+// Start → proses outbox tiap Interval sampai ctx di-cancel
+func (r *OutboxRelay) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(r.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				published, failed, err := r.ProcessDue(ctx)
+				if err != nil {
+					log.Println("outbox relay:", err)
+				}
+				if failed > 0 {
+					log.Printf("outbox relay: published %d, failed %d\n", published, failed)
+				}
+			}
+		}
+	}()
+}
+
+// ProcessDue → publish semua event pending yang jatuh tempo (urut waktu dibuat)
+func (r *OutboxRelay) ProcessDue(ctx context.Context) (int, int, error) {
+	published, failed := 0, 0
+	for {
+		event, err := r.claimNext(ctx)
+		if err == mongo.ErrNoDocuments {
+			return published, failed, nil
+		}
+		if err != nil {
+			return published, failed, err
+		}
+
+		publishCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		err = r.Publisher.Publish(publishCtx, ToEvent(event))
+		cancel()
+		if err != nil {
+			failed++
+			r.markFailed(ctx, event, err)
+			continue
+		}
+		published++
+		r.markPublished(ctx, event)
+	}
+}
+
+func (r *OutboxRelay) claimNext(ctx context.Context) (models.OutboxEvent, error) {
+	now := time.Now()
+	var event models.OutboxEvent
+	err := r.Collection.FindOneAndUpdate(ctx,
+		bson.M{"status": "pending", "nextAttemptAt": bson.M{"$lte": now}},
+		bson.M{
+			"$set": bson.M{"nextAttemptAt": now.Add(outboxLease)},
+			"$inc": bson.M{"attempts": 1},
+		},
+		options.FindOneAndUpdate().
+			SetSort(bson.D{{Key: "createdAt", Value: 1}}).
+			SetReturnDocument(options.After),
+	).Decode(&event)
+	return event, err
+}
+
+func (r *OutboxRelay) markPublished(ctx context.Context, event models.OutboxEvent) {
+	_, err := r.Collection.UpdateOne(ctx,
+		bson.M{"_id": event.ID},
+		bson.M{
+			"$set":   bson.M{"status": "published", "publishedAt": time.Now()},
+			"$unset": bson.M{"lastError": ""},
+		},
+	)
+	if err != nil {
+		log.Printf("outbox relay: mark %s published: %v\n", event.ID.Hex(), err)
+	}
+}
+
+// markFailed → jadwal ulang dengan backoff, berhenti (failed) setelah MaxAttempts
+func (r *OutboxRelay) markFailed(ctx context.Context, event models.OutboxEvent, publishErr error) {
+	log.Printf("outbox relay: publish %s (%s): %v\n", event.ID.Hex(), event.Type, publishErr)
+
+	set := bson.M{"lastError": publishErr.Error()}
+	if event.Attempts >= r.MaxAttempts {
+		set["status"] = "failed"
+	} else {
+		set["nextAttemptAt"] = time.Now().Add(Backoff(event.Attempts, outboxBaseBackoff, outboxMaxBackoff))
+	}
+	if _, err := r.Collection.UpdateOne(ctx, bson.M{"_id": event.ID}, bson.M{"$set": set}); err != nil {
+		log.Printf("outbox relay: mark %s failed: %v\n", event.ID.Hex(), err)
+	}
+}
+
+// ToEvent → dokumen outbox jadi events.Event
+func ToEvent(event models.OutboxEvent) events.Event {
+	return events.Event{
+		ID:            event.ID.Hex(),
+		Type:          event.Type,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID.Hex(),
+		Data:          event.Data,
+		OccurredAt:    event.CreatedAt,
+	}
+}
+
+// eventString → ambil field string dari Event.Data
+func eventString(event events.Event, key string) string {
+	s, _ := event.Data[key].(string)
+	return s
+}
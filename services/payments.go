@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"airplane_booking_go/models"
+	"airplane_booking_go/payments"
+)
+
+// PaymentService → jembatan antara payment provider dan collection payments
+type PaymentService struct {
+	Provider          payments.Provider
+	PaymentCollection *mongo.Collection
+	Currency          string
+}
+
+func NewPaymentService(provider payments.Provider, paymentColl *mongo.Collection, currency string) *PaymentService {
+	return &PaymentService{
+		Provider:          provider,
+		PaymentCollection: paymentColl,
+		Currency:          currency,
+	}
+}
+
+// Charge → authorize + capture, hasilnya (sukses/gagal) selalu dicatat di collection payments
+func (s *PaymentService) Charge(ctx context.Context, booking models.Booking, amount float64, paymentToken string) (models.Payment, error) {
+	payment := models.Payment{
+		ID:        primitive.NewObjectID(),
+		BookingID: booking.ID,
+		UserID:    booking.UserID,
+		Provider:  s.Provider.Name(),
+		Amount:    amount,
+		Currency:  s.Currency,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	chargeErr := func() error {
+		auth, err := s.Provider.Authorize(ctx, payments.AuthorizeRequest{
+			Reference:    booking.ID.Hex(),
+			Amount:       amount,
+			Currency:     s.Currency,
+			PaymentToken: paymentToken,
+		})
+		if err != nil {
+			return err
+		}
+		payment.AuthorizationID = auth.ID
+
+		capture, err := s.Provider.Capture(ctx, auth.ID, amount)
+		if err != nil {
+			return err
+		}
+		payment.CaptureID = capture.ID
+		return nil
+	}()
+
+	if chargeErr != nil {
+		payment.Status = "failed"
+		payment.FailureReason = chargeErr.Error()
+	} else {
+		payment.Status = "captured"
+	}
+
+	if _, err := s.PaymentCollection.InsertOne(ctx, payment); err != nil {
+		return payment, err
+	}
+	return payment, chargeErr
+}
+
+// RefundPayment → refund sebagian/penuh dari satu payment
+func (s *PaymentService) RefundPayment(ctx context.Context, payment models.Payment, amount float64, reason string) (models.Payment, error) {
+	remaining := payment.Amount - payment.RefundedAmount
+	if amount <= 0 || amount > remaining+0.005 {
+		return payment, payments.ErrInvalidAmount
+	}
+
+	refund, err := s.Provider.Refund(ctx, payment.CaptureID, amount)
+	if err != nil {
+		return payment, err
+	}
+
+	payment.RefundedAmount += refund.Amount
+	payment.Status = "partially_refunded"
+	if payment.RefundedAmount >= payment.Amount-0.005 {
+		payment.Status = "refunded"
+	}
+	payment.UpdatedAt = time.Now()
+
+	entry := models.PaymentRefund{
+		ID:        refund.ID,
+		Amount:    refund.Amount,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	}
+	payment.Refunds = append(payment.Refunds, entry)
+
+	_, err = s.PaymentCollection.UpdateOne(ctx,
+		bson.M{"_id": payment.ID},
+		bson.M{
+			"$set":  bson.M{"status": payment.Status, "refundedAmount": payment.RefundedAmount, "updatedAt": payment.UpdatedAt},
+			"$push": bson.M{"refunds": entry},
+		},
+	)
+	return payment, err
+}
+
+// RefundBooking → refund amount dari payment-payment booking (yang terbaru duluan), return total yang berhasil di-refund
+func (s *PaymentService) RefundBooking(ctx context.Context, bookingID primitive.ObjectID, amount float64, reason string) (float64, error) {
+	if amount <= 0 {
+		return 0, nil
+	}
+
+	cursor, err := s.PaymentCollection.Find(ctx,
+		bson.M{"bookingId": bookingID, "status": bson.M{"$in": []string{"captured", "partially_refunded"}}},
+		options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}}),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	var captured []models.Payment
+	if err := cursor.All(ctx, &captured); err != nil {
+		return 0, err
+	}
+
+	refunded := 0.0
+	for _, payment := range captured {
+		left := round2(amount - refunded)
+		if left <= 0 {
+			break
+		}
+		portion := math.Min(left, round2(payment.Amount-payment.RefundedAmount))
+		if portion <= 0 {
+			continue
+		}
+		if _, err := s.RefundPayment(ctx, payment, portion, reason); err != nil {
+			return refunded, err
+		}
+		refunded = round2(refunded + portion)
+	}
+
+	if refunded < round2(amount) {
+		return refunded, errors.New("not enough captured funds to refund")
+	}
+	return refunded, nil
+}
+
+// RefundableAmount → total yang sudah di-capture untuk booking dan belum di-refund
+func (s *PaymentService) RefundableAmount(ctx context.Context, bookingID primitive.ObjectID) (float64, error) {
+	cursor, err := s.PaymentCollection.Find(ctx,
+		bson.M{"bookingId": bookingID, "status": bson.M{"$in": []string{"captured", "partially_refunded"}}},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	var captured []models.Payment
+	if err := cursor.All(ctx, &captured); err != nil {
+		return 0, err
+	}
+
+	total := 0.0
+	for _, payment := range captured {
+		total += payment.Amount - payment.RefundedAmount
+	}
+	return round2(total), nil
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// ConfirmPaidBooking → set booking jadi confirmed setelah payment captured (kalau masih nunggu bayar & hold belum lewat)
+func ConfirmPaidBooking(ctx context.Context, bookingColl *mongo.Collection, booking models.Booking, paymentID primitive.ObjectID, actor string) error {
+	return TransitionBooking(ctx, bookingColl, booking.ID, booking.Status, Transition{
+		To:     models.BookingStatusConfirmed,
+		Actor:  actor,
+		Reason: "payment captured",
+		Filter: bson.M{"holdExpiresAt": bson.M{"$gt": time.Now()}},
+		Set:    bson.M{"paymentId": paymentID},
+		Unset:  []string{"holdExpiresAt"},
+	})
+}
@@ -0,0 +1,277 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"airplane_booking_go/models"
+)
+
+const (
+	ReaccommodationRebook = "rebook"
+	ReaccommodationRefund = "refund"
+)
+
+// Reaccommodator → proses booking di flight yang dibatalkan maskapai:
+// pindah ke flight berikutnya di rute yang sama, atau cancel + refund penuh
+type Reaccommodator struct {
+	BookingCollection *mongo.Collection
+	FlightCollection  *mongo.Collection
+	PaymentService    *PaymentService
+	Waitlist          *Waitlist
+	Window            time.Duration
+}
+
+func NewReaccommodator(bookingColl, flightColl *mongo.Collection, paymentService *PaymentService, waitlist *Waitlist, window time.Duration) *Reaccommodator {
+	return &Reaccommodator{
+		BookingCollection: bookingColl,
+		FlightCollection:  flightColl,
+		PaymentService:    paymentService,
+		Waitlist:          waitlist,
+		Window:            window,
+	}
+}
+
+// ReaccommodationOutcome → hasil per booking
+type ReaccommodationOutcome struct {
+	BookingID       primitive.ObjectID  `json:"bookingId"`
+	RecordLocator   string              `json:"recordLocator,omitempty"`
+	Outcome         string              `json:"outcome"` // rebooked, refunded, cancelled, failed
+	NewFlightID     *primitive.ObjectID `json:"newFlightId,omitempty"`
+	NewFlightNumber string              `json:"newFlightNumber,omitempty"`
+	NewDeparture    *time.Time          `json:"newDepartureTime,omitempty"`
+	SeatSwaps       []models.SeatSwap   `json:"seatSwaps,omitempty"`
+	Refunded        float64             `json:"refunded,omitempty"`
+	RebookError     string              `json:"rebookError,omitempty"` // kenapa gak bisa dipindah sebelum di-refund
+	Error           string              `json:"error,omitempty"`
+}
+
+// Reaccommodate → proses semua booking yang masih pegang kursi di flight yang sudah cancelled.
+// Aman dipanggil ulang: booking yang sudah dipindah / di-cancel gak ikut lagi.
+func (r *Reaccommodator) Reaccommodate(ctx context.Context, flight models.Flight, policy string, window time.Duration, actor string) ([]ReaccommodationOutcome, error) {
+	if window <= 0 {
+		window = r.Window
+	}
+
+	if _, err := r.Waitlist.CancelFlightEntries(ctx, flight.ID); err != nil {
+		return nil, err
+	}
+
+	bookings, err := FindFlightBookings(ctx, r.BookingCollection, flight.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	outcomes := make([]ReaccommodationOutcome, 0, len(bookings))
+	for _, booking := range bookings {
+		outcome := ReaccommodationOutcome{BookingID: booking.ID, RecordLocator: booking.RecordLocator}
+
+		// booking itinerary belum bisa ganti flight per segmen → langsung refund
+		if policy != ReaccommodationRefund && !booking.IsItinerary() {
+			rebooked, err := r.rebook(ctx, booking, flight, window, actor)
+			if err == nil {
+				outcome.Outcome = "rebooked"
+				outcome.NewFlightID = &rebooked.flight.ID
+				outcome.NewFlightNumber = rebooked.flight.FlightNumber
+				outcome.NewDeparture = &rebooked.flight.DepartureTime
+				outcome.SeatSwaps = rebooked.swaps
+				outcomes = append(outcomes, outcome)
+				continue
+			}
+			outcome.RebookError = err.Error()
+			log.Printf("reaccommodation: booking %s not rebooked: %v\n", booking.ID.Hex(), err)
+		}
+
+		refunded, err := r.cancelWithRefund(ctx, booking, flight, actor)
+		outcome.Refunded = refunded
+		switch {
+		case err != nil:
+			outcome.Outcome = "failed"
+			outcome.Error = err.Error()
+		case refunded > 0:
+			outcome.Outcome = "refunded"
+		default:
+			outcome.Outcome = "cancelled"
+		}
+		outcomes = append(outcomes, outcome)
+	}
+	return outcomes, nil
+}
+
+type rebookResult struct {
+	flight models.Flight
+	swaps  []models.SeatSwap
+}
+
+// rebook → coba flight pengganti satu per satu (paling awal duluan) sampai ada yang muat
+func (r *Reaccommodator) rebook(ctx context.Context, booking models.Booking, cancelled models.Flight, window time.Duration, actor string) (rebookResult, error) {
+	earliest := cancelled.DepartureTime
+	if now := time.Now(); now.After(earliest) {
+		earliest = now
+	}
+
+	cursor, err := r.FlightCollection.Find(ctx,
+		bson.M{
+			"_id":            bson.M{"$ne": cancelled.ID},
+			"departure.code": cancelled.Departure.Code,
+			"arrival.code":   cancelled.Arrival.Code,
+			"departureTime":  bson.M{"$gt": earliest, "$lte": cancelled.DepartureTime.Add(window)},
+			"status": bson.M{"$nin": []models.FlightStatus{
+				models.FlightStatusDeparted, models.FlightStatusArrived, models.FlightStatusCancelled, models.FlightStatusDiverted,
+			}},
+		},
+		options.Find().SetSort(bson.D{{Key: "departureTime", Value: 1}}).SetLimit(20),
+	)
+	if err != nil {
+		return rebookResult{}, err
+	}
+	var candidates []models.Flight
+	if err := cursor.All(ctx, &candidates); err != nil {
+		return rebookResult{}, err
+	}
+
+	var lastErr error
+	for _, candidate := range candidates {
+		plan, swaps, err := planRebooking(booking, cancelled, candidate)
+		if err != nil {
+			lastErr = fmt.Errorf("flight %s: %w", candidate.FlightNumber, err)
+			continue
+		}
+		change := models.BookingChange{
+			Type:         "reaccommodation",
+			FromFlightID: cancelled.ID,
+			ToFlightID:   candidate.ID,
+			SeatSwaps:    swaps,
+			Actor:        actor,
+			At:           time.Now(),
+		}
+
+		if err := ApplyFlightChange(ctx, r.BookingCollection, r.FlightCollection, booking, plan, change); err != nil {
+			if errors.Is(err, ErrBookingChanged) {
+				return rebookResult{}, err
+			}
+			// kursi keburu diambil → coba flight berikutnya
+			lastErr = fmt.Errorf("flight %s: %w", candidate.FlightNumber, err)
+			continue
+		}
+		return rebookResult{flight: candidate, swaps: swaps}, nil
+	}
+	if lastErr != nil {
+		return rebookResult{}, fmt.Errorf("no replacement flight with enough seats (last: %v)", lastErr)
+	}
+	return rebookResult{}, errors.New("no replacement flight with enough seats")
+}
+
+// planRebooking → kursi sejenis di flight pengganti, harga yang sudah dibayar tetap
+func planRebooking(booking models.Booking, cancelled, candidate models.Flight) (FlightChangePlan, []models.SeatSwap, error) {
+	seatNumbers, err := assignLikeSeats(candidate, booking.Seats)
+	if err != nil {
+		return FlightChangePlan{}, nil, err
+	}
+	plan, err := PlanFlightChange(booking, cancelled, candidate, seatNumbers)
+	if err != nil {
+		return FlightChangePlan{}, nil, err
+	}
+
+	// dipindah maskapai → penumpang gak bayar selisih / fee, harga yang dibayar tetap
+	for i := range plan.NewSeats {
+		if i < len(booking.Seats) {
+			plan.NewSeats[i].Price = booking.Seats[i].Price
+		}
+	}
+	plan.FareDifference = 0
+
+	swaps := make([]models.SeatSwap, 0, len(booking.Seats))
+	for i, seat := range booking.Seats {
+		swaps = append(swaps, models.SeatSwap{From: seat.Number, To: seatNumbers[i]})
+	}
+	return plan, swaps, nil
+}
+
+// assignLikeSeats → kursi di flight lain dengan class yang sama per penumpang, grup tetap diusahakan berdampingan
+func assignLikeSeats(flight models.Flight, seats []models.Seat) ([]string, error) {
+	byClass := map[string][]int{}
+	var classes []string
+	for i, seat := range seats {
+		if _, ok := byClass[seat.Class]; !ok {
+			classes = append(classes, seat.Class)
+		}
+		byClass[seat.Class] = append(byClass[seat.Class], i)
+	}
+
+	numbers := make([]string, len(seats))
+	for _, class := range classes {
+		assigned, err := AssignSeats(flight, class, len(byClass[class]))
+		if err != nil {
+			return nil, err
+		}
+		for j, idx := range byClass[class] {
+			numbers[idx] = assigned[j].Number
+		}
+	}
+	return numbers, nil
+}
+
+// cancelWithRefund → cancel booking karena flight batal, semua yang sudah dibayar dikembalikan
+func (r *Reaccommodator) cancelWithRefund(ctx context.Context, booking models.Booking, flight models.Flight, actor string) (float64, error) {
+	refundAmount := 0.0
+	if booking.PaymentID != nil {
+		amount, err := r.PaymentService.RefundableAmount(ctx, booking.ID)
+		if err != nil {
+			return 0, err
+		}
+		refundAmount = amount
+	}
+
+	session, err := r.BookingCollection.Database().Client().StartSession()
+	if err != nil {
+		return 0, err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		err := TransitionBooking(sessCtx, r.BookingCollection, booking.ID, booking.Status, Transition{
+			To:     models.BookingStatusCancelled,
+			Actor:  actor,
+			Reason: fmt.Sprintf("flight %s cancelled by airline", flight.FlightNumber),
+			Set:    bson.M{"refundAmount": refundAmount},
+			Unset:  []string{"holdExpiresAt"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return nil, ReleaseBookingSeats(sessCtx, r.FlightCollection, booking)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// kursi di segmen lain (itinerary) kebuka → tawarkan ke waitlist
+	r.Waitlist.OfferFreedSeatsForBooking(ctx, booking)
+
+	if refundAmount <= 0 {
+		return 0, nil
+	}
+	refunded, err := r.PaymentService.RefundBooking(ctx, booking.ID, refundAmount, "flight cancelled by airline")
+	if err != nil {
+		return refunded, fmt.Errorf("booking cancelled but refund failed: %v", err)
+	}
+
+	err = TransitionBooking(ctx, r.BookingCollection, booking.ID, models.BookingStatusCancelled, Transition{
+		To:     models.BookingStatusRefunded,
+		Actor:  ActorSystem,
+		Reason: fmt.Sprintf("refund of %.2f issued", refunded),
+	})
+	if err != nil {
+		return refunded, errors.New("refund issued but failed to update booking status")
+	}
+	return refunded, nil
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"airplane_booking_go/models"
+)
+
+func TestPlanRebooking(t *testing.T) {
+	now := time.Now()
+	route := func(number string, status models.FlightStatus, depart time.Time, seats []models.Seat) models.Flight {
+		return models.Flight{
+			ID:            primitive.NewObjectID(),
+			FlightNumber:  number,
+			Departure:     models.Airport{Code: "CGK"},
+			Arrival:       models.Airport{Code: "DPS"},
+			DepartureTime: depart,
+			ArrivalTime:   depart.Add(2 * time.Hour),
+			Status:        status,
+			Seats:         seats,
+		}
+	}
+
+	// delay lewat jam berangkat lalu dibatalkan → tetap harus bisa dipindah
+	cancelled := route("GA400", models.FlightStatusCancelled, now.Add(-3*time.Hour), nil)
+	booking := models.Booking{
+		ID:       primitive.NewObjectID(),
+		FlightID: cancelled.ID,
+		Seats: []models.Seat{
+			{Number: "30A", Class: "economy", Price: 80},
+			{Number: "30B", Class: "economy", Price: 90},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		candidate models.Flight
+		wantSeats []string
+		wantErr   bool
+	}{
+		{
+			name:      "later flight after a cancellation past scheduled departure",
+			candidate: route("GA402", models.FlightStatusScheduled, now.Add(4*time.Hour), seatsOf("1A", "1B", "1C")),
+			wantSeats: []string{"1A", "1B"},
+		},
+		{
+			name:      "candidate without enough seats",
+			candidate: route("GA404", models.FlightStatusScheduled, now.Add(6*time.Hour), seatsOf("1A", "x1B")),
+			wantErr:   true,
+		},
+		{
+			name:      "candidate already departed",
+			candidate: route("GA406", models.FlightStatusDeparted, now.Add(-time.Hour), seatsOf("1A", "1B")),
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, swaps, err := planRebooking(booking, cancelled, tt.candidate)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("planRebooking() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("planRebooking() error = %v", err)
+			}
+			if got := seatNumbers(plan.NewSeats); !reflect.DeepEqual(got, tt.wantSeats) {
+				t.Errorf("NewSeats = %v, want %v", got, tt.wantSeats)
+			}
+			// harga yang sudah dibayar dipertahankan, tanpa selisih
+			if plan.FareDifference != 0 || plan.NewSeats[0].Price != 80 || plan.NewSeats[1].Price != 90 {
+				t.Errorf("FareDifference / prices = %v / %v, %v, want 0 / 80, 90", plan.FareDifference, plan.NewSeats[0].Price, plan.NewSeats[1].Price)
+			}
+			want := []models.SeatSwap{{From: "30A", To: "1A"}, {From: "30B", To: "1B"}}
+			if !reflect.DeepEqual(swaps, want) {
+				t.Errorf("swaps = %v, want %v", swaps, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"airplane_booking_go/utils"
+)
+
+// NewRecordLocator → generate locator yang belum dipakai booking lain.
+// Unique index tetap jadi pengaman terakhir kalau ada race.
+func NewRecordLocator(ctx context.Context, bookingColl *mongo.Collection) (string, error) {
+	for attempt := 0; attempt < 10; attempt++ {
+		locator, err := utils.GenerateRecordLocator()
+		if err != nil {
+			return "", err
+		}
+
+		count, err := bookingColl.CountDocuments(ctx, bson.M{"recordLocator": locator})
+		if err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return locator, nil
+		}
+	}
+	return "", errors.New("failed to generate unique record locator")
+}
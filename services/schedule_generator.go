@@ -0,0 +1,252 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"airplane_booking_go/events"
+	"airplane_booking_go/models"
+)
+
+// ScheduleGenerator → bikin Flight dari jadwal rutin untuk rentang Horizon ke depan.
+// Aman dijalankan berulang: tanggal yang sudah punya flight dengan nomor yang sama dilewati.
+type ScheduleGenerator struct {
+	ScheduleCollection *mongo.Collection
+	FlightCollection   *mongo.Collection
+	AircraftCollection *mongo.Collection
+	Horizon            time.Duration
+	Interval           time.Duration
+}
+
+func NewScheduleGenerator(scheduleColl, flightColl, aircraftColl *mongo.Collection, horizon, interval time.Duration) *ScheduleGenerator {
+	return &ScheduleGenerator{
+		ScheduleCollection: scheduleColl,
+		FlightCollection:   flightColl,
+		AircraftCollection: aircraftColl,
+		Horizon:            horizon,
+		Interval:           interval,
+	}
+}
+
+// GenerateResult → ringkasan satu kali generate
+type GenerateResult struct {
+	ScheduleID primitive.ObjectID `json:"scheduleId"`
+	Created    []string           `json:"created"` // tanggal lokal yang flight-nya baru dibuat
+	Skipped    []string           `json:"skipped"` // tanggal yang sudah punya flight
+}
+
+// Start → generate sekali di awal, lalu tiap Interval sampai ctx di-cancel
+func (g *ScheduleGenerator) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(g.Interval)
+		defer ticker.Stop()
+
+		for {
+			if n, err := g.GenerateAll(ctx); err != nil {
+				log.Println("schedule generator:", err)
+			} else if n > 0 {
+				log.Printf("schedule generator: created %d flight(s)\n", n)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// GenerateAll → generate semua jadwal aktif, return jumlah flight baru
+func (g *ScheduleGenerator) GenerateAll(ctx context.Context) (int, error) {
+	findCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := g.ScheduleCollection.Find(findCtx, bson.M{"active": true})
+	if err != nil {
+		return 0, err
+	}
+	var schedules []models.FlightSchedule
+	if err := cursor.All(findCtx, &schedules); err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for _, schedule := range schedules {
+		result, err := g.Generate(ctx, schedule, time.Now(), g.Horizon)
+		if err != nil {
+			// satu jadwal rusak jangan sampai nahan jadwal lain
+			log.Printf("schedule generator: schedule %s (%s): %v\n", schedule.ID.Hex(), schedule.FlightNumber, err)
+			continue
+		}
+		created += len(result.Created)
+	}
+	return created, nil
+}
+
+// Generate → bikin flight untuk tanggal-tanggal jadwal dalam [from, from+horizon]
+func (g *ScheduleGenerator) Generate(ctx context.Context, schedule models.FlightSchedule, from time.Time, horizon time.Duration) (GenerateResult, error) {
+	result := GenerateResult{ScheduleID: schedule.ID, Created: []string{}, Skipped: []string{}}
+
+	dates, err := ScheduleDates(schedule, from, from.Add(horizon))
+	if err != nil || len(dates) == 0 {
+		return result, err
+	}
+
+	// seat map sama untuk semua tanggal, cukup generate sekali
+	layout, seats, err := g.seatMap(ctx, schedule)
+	if err != nil {
+		return result, err
+	}
+
+	for _, departure := range dates {
+		serviceDate := departure.Format("2006-01-02")
+		exists, err := g.flightExists(ctx, schedule, departure)
+		if err != nil {
+			return result, err
+		}
+		if exists {
+			result.Skipped = append(result.Skipped, serviceDate)
+			continue
+		}
+
+		flightSeats := make([]models.Seat, len(seats))
+		copy(flightSeats, seats)
+		flightLayout := layout
+		scheduleID := schedule.ID
+		flight := models.Flight{
+			ID:             primitive.NewObjectID(),
+			Airline:        schedule.Airline,
+			CarrierCode:    schedule.CarrierCode,
+			AirlineLogoURL: schedule.AirlineLogoURL,
+			FlightNumber:   schedule.FlightNumber,
+			AircraftType:   schedule.AircraftType,
+			Departure:      schedule.Departure,
+			Arrival:        schedule.Arrival,
+			DepartureTime:  departure.UTC(),
+			ArrivalTime:    departure.Add(time.Duration(schedule.Duration) * time.Minute).UTC(),
+			Duration:       schedule.Duration,
+			MinPrice:       MinSeatPrice(flightSeats),
+			Status:         models.FlightStatusScheduled,
+			Seats:          flightSeats,
+			CabinLayout:    &flightLayout,
+			ScheduleID:     &scheduleID,
+			ServiceDate:    serviceDate,
+			CreatedAt:      time.Now(),
+			UpdatedAt:      time.Now(),
+		}
+
+		insertCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		err = InTransaction(insertCtx, g.FlightCollection.Database().Client(), func(ctx context.Context) error {
+			if _, err := g.FlightCollection.InsertOne(ctx, flight); err != nil {
+				return err
+			}
+			return RecordEvent(ctx, g.FlightCollection.Database(), events.FlightCreated, events.AggregateFlight, flight.ID, map[string]interface{}{
+				"source":     "schedule",
+				"scheduleId": schedule.ID.Hex(),
+			})
+		})
+		cancel()
+		if mongo.IsDuplicateKeyError(err) {
+			// generator lain keburu bikin
+			result.Skipped = append(result.Skipped, serviceDate)
+			continue
+		}
+		if err != nil {
+			return result, err
+		}
+		result.Created = append(result.Created, serviceDate)
+	}
+	return result, nil
+}
+
+func (g *ScheduleGenerator) seatMap(ctx context.Context, schedule models.FlightSchedule) (models.CabinLayout, []models.Seat, error) {
+	findCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, layout, err := AircraftLayout(findCtx, g.AircraftCollection, schedule.AircraftType, schedule.Fares)
+	if err != nil {
+		return models.CabinLayout{}, nil, err
+	}
+	seats, err := GenerateSeats(layout)
+	if err != nil {
+		return models.CabinLayout{}, nil, err
+	}
+	return layout, seats, nil
+}
+
+// flightExists → sudah ada flight dengan nomor ini di tanggal lokal yang sama (hasil jadwal maupun dibuat manual)
+func (g *ScheduleGenerator) flightExists(ctx context.Context, schedule models.FlightSchedule, departure time.Time) (bool, error) {
+	dayStart := time.Date(departure.Year(), departure.Month(), departure.Day(), 0, 0, 0, 0, departure.Location())
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	findCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	count, err := g.FlightCollection.CountDocuments(findCtx, bson.M{
+		"flightNumber": schedule.FlightNumber,
+		"$or": []bson.M{
+			{"serviceDate": departure.Format("2006-01-02")},
+			{"departureTime": bson.M{"$gte": dayStart.UTC(), "$lt": dayEnd.UTC()}},
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// ScheduleDates → waktu keberangkatan (zona lokal jadwal) untuk setiap hari yang cocok di [from, to],
+// dibatasi validFrom/validTo dan cuma yang belum lewat
+func ScheduleDates(schedule models.FlightSchedule, from, to time.Time) ([]time.Time, error) {
+	loc, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timeZone %q", schedule.TimeZone)
+	}
+	clock, err := time.Parse("15:04", schedule.DepartureTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid departureTime %q", schedule.DepartureTime)
+	}
+	validFrom, err := time.ParseInLocation("2006-01-02", schedule.ValidFrom, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid validFrom %q", schedule.ValidFrom)
+	}
+	validTo, err := time.ParseInLocation("2006-01-02", schedule.ValidTo, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid validTo %q", schedule.ValidTo)
+	}
+	if validTo.Before(validFrom) {
+		return nil, errors.New("validTo must not be before validFrom")
+	}
+
+	days := map[time.Weekday]bool{}
+	for _, d := range schedule.DaysOfWeek {
+		days[time.Weekday(d%7)] = true // 7 (Minggu) → time.Sunday (0)
+	}
+
+	from, to = from.In(loc), to.In(loc)
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	if day.Before(validFrom) {
+		day = validFrom
+	}
+
+	var dates []time.Time
+	for ; !day.After(validTo) && !day.After(to); day = day.AddDate(0, 0, 1) {
+		if !days[day.Weekday()] {
+			continue
+		}
+		departure := time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, loc)
+		if !departure.After(from) {
+			continue
+		}
+		dates = append(dates, departure)
+	}
+	return dates, nil
+}
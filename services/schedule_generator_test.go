@@ -0,0 +1,137 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"airplane_booking_go/models"
+)
+
+func TestScheduleDates(t *testing.T) {
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	newYork, _ := time.LoadLocation("America/New_York")
+
+	base := models.FlightSchedule{
+		DepartureTime: "08:30",
+		TimeZone:      "Asia/Jakarta",
+		DaysOfWeek:    []int{1, 3, 5},
+		ValidFrom:     "2026-03-01",
+		ValidTo:       "2026-03-31",
+	}
+	with := func(edit func(s *models.FlightSchedule)) models.FlightSchedule {
+		s := base
+		edit(&s)
+		return s
+	}
+
+	tests := []struct {
+		name     string
+		schedule models.FlightSchedule
+		from, to time.Time
+		want     []string // RFC3339 di zona jadwal
+	}{
+		{
+			name:     "mon wed fri in one week",
+			schedule: base,
+			from:     time.Date(2026, 3, 2, 0, 0, 0, 0, jakarta),
+			to:       time.Date(2026, 3, 8, 23, 59, 0, 0, jakarta),
+			want:     []string{"2026-03-02T08:30:00+07:00", "2026-03-04T08:30:00+07:00", "2026-03-06T08:30:00+07:00"},
+		},
+		{
+			name:     "7 means sunday",
+			schedule: with(func(s *models.FlightSchedule) { s.DaysOfWeek = []int{7} }),
+			from:     time.Date(2026, 3, 1, 0, 0, 0, 0, jakarta),
+			to:       time.Date(2026, 3, 10, 0, 0, 0, 0, jakarta),
+			want:     []string{"2026-03-01T08:30:00+07:00", "2026-03-08T08:30:00+07:00"},
+		},
+		{
+			name:     "departure already passed on the first day is skipped",
+			schedule: base,
+			from:     time.Date(2026, 3, 2, 9, 0, 0, 0, jakarta),
+			to:       time.Date(2026, 3, 4, 23, 0, 0, 0, jakarta),
+			want:     []string{"2026-03-04T08:30:00+07:00"},
+		},
+		{
+			name:     "window clipped to validFrom / validTo",
+			schedule: with(func(s *models.FlightSchedule) { s.ValidFrom, s.ValidTo = "2026-03-04", "2026-03-05" }),
+			from:     time.Date(2026, 3, 1, 0, 0, 0, 0, jakarta),
+			to:       time.Date(2026, 3, 31, 0, 0, 0, 0, jakarta),
+			want:     []string{"2026-03-04T08:30:00+07:00"},
+		},
+		{
+			name:     "from given in UTC is converted to the schedule zone",
+			schedule: base,
+			from:     time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC), // 2 Maret 03:00 WIB
+			to:       time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC),
+			want:     []string{"2026-03-02T08:30:00+07:00"},
+		},
+		{
+			name: "local clock time kept across DST change",
+			schedule: with(func(s *models.FlightSchedule) {
+				s.TimeZone, s.DepartureTime, s.DaysOfWeek = "America/New_York", "08:00", []int{1, 2, 3, 4, 5, 6, 7}
+			}),
+			from: time.Date(2026, 3, 7, 0, 0, 0, 0, newYork),
+			to:   time.Date(2026, 3, 9, 23, 0, 0, 0, newYork),
+			want: []string{"2026-03-07T08:00:00-05:00", "2026-03-08T08:00:00-04:00", "2026-03-09T08:00:00-04:00"},
+		},
+		{
+			name:     "outside validity returns nothing",
+			schedule: base,
+			from:     time.Date(2026, 4, 1, 0, 0, 0, 0, jakarta),
+			to:       time.Date(2026, 4, 30, 0, 0, 0, 0, jakarta),
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dates, err := ScheduleDates(tt.schedule, tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("ScheduleDates() error = %v", err)
+			}
+			var got []string
+			for _, d := range dates {
+				got = append(got, d.Format(time.RFC3339))
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ScheduleDates() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("ScheduleDates()[%d] = %s, want %s", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestScheduleDatesInvalid(t *testing.T) {
+	base := models.FlightSchedule{
+		DepartureTime: "08:30",
+		TimeZone:      "Asia/Jakarta",
+		DaysOfWeek:    []int{1},
+		ValidFrom:     "2026-03-01",
+		ValidTo:       "2026-03-31",
+	}
+	tests := []struct {
+		name string
+		edit func(s *models.FlightSchedule)
+	}{
+		{"unknown time zone", func(s *models.FlightSchedule) { s.TimeZone = "Mars/Olympus" }},
+		{"bad departure time", func(s *models.FlightSchedule) { s.DepartureTime = "8.30" }},
+		{"bad validFrom", func(s *models.FlightSchedule) { s.ValidFrom = "01-03-2026" }},
+		{"bad validTo", func(s *models.FlightSchedule) { s.ValidTo = "" }},
+		{"validTo before validFrom", func(s *models.FlightSchedule) { s.ValidTo = "2026-02-01" }},
+	}
+
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := base
+			tt.edit(&schedule)
+			if _, err := ScheduleDates(schedule, now, now.AddDate(0, 0, 7)); err == nil {
+				t.Error("ScheduleDates() error = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"airplane_booking_go/models"
+)
+
+var (
+	rowSeatPattern    = regexp.MustCompile(`^(\d+)([A-Z])$`)  // "12A"
+	legacySeatPattern = regexp.MustCompile(`^([A-Z]+)(\d+)$`) // "E12" (format lama)
+)
+
+type seatSlot struct {
+	seat models.Seat
+	row  int
+	col  int
+}
+
+// AssignSeats → pilih count kursi kosong di class tertentu.
+// Prioritas: satu blok berdampingan di satu baris → satu baris yang sama → baris-baris terdekat.
+func AssignSeats(flight models.Flight, class string, count int) ([]models.Seat, error) {
+	if count < 1 {
+		return nil, fmt.Errorf("seat count must be at least 1")
+	}
+
+	var slots []seatSlot
+	for _, seat := range flight.Seats {
+		if seat.Class != class || !seat.IsBookable() {
+			continue
+		}
+		row, col := seatCoordinates(seat)
+		slots = append(slots, seatSlot{seat: seat, row: row, col: col})
+	}
+	if len(slots) < count {
+		return nil, fmt.Errorf("only %d %s seat(s) available", len(slots), class)
+	}
+
+	sort.Slice(slots, func(i, j int) bool {
+		if slots[i].row != slots[j].row {
+			return slots[i].row < slots[j].row
+		}
+		return slots[i].col < slots[j].col
+	})
+
+	rows := groupRows(slots)
+
+	// 1. blok berdampingan di satu baris
+	for _, row := range rows {
+		for start := 0; start+count <= len(row); start++ {
+			if contiguous(row[start : start+count]) {
+				return slotSeats(row[start : start+count]), nil
+			}
+		}
+	}
+
+	// 2. satu baris walaupun gak berdampingan
+	for _, row := range rows {
+		if len(row) >= count {
+			return slotSeats(row[:count]), nil
+		}
+	}
+
+	// 3. baris-baris berurutan mulai dari depan
+	return slotSeats(slots[:count]), nil
+}
+
+func seatCoordinates(seat models.Seat) (int, int) {
+	// kursi dari cabin layout sudah punya koordinat (lorong ikut dihitung)
+	if seat.Row > 0 && seat.Column > 0 {
+		return seat.Row, seat.Column
+	}
+
+	number := seat.Number
+	if m := rowSeatPattern.FindStringSubmatch(number); m != nil {
+		row, _ := strconv.Atoi(m[1])
+		return row, int(m[2][0] - 'A')
+	}
+	if m := legacySeatPattern.FindStringSubmatch(number); m != nil {
+		// format lama gak punya baris, anggap satu baris panjang
+		col, _ := strconv.Atoi(m[2])
+		return 0, col
+	}
+	return 0, 0
+}
+
+func groupRows(slots []seatSlot) [][]seatSlot {
+	var rows [][]seatSlot
+	for i, slot := range slots {
+		if i == 0 || slot.row != slots[i-1].row {
+			rows = append(rows, []seatSlot{})
+		}
+		rows[len(rows)-1] = append(rows[len(rows)-1], slot)
+	}
+	return rows
+}
+
+func contiguous(slots []seatSlot) bool {
+	for i := 1; i < len(slots); i++ {
+		if slots[i].col != slots[i-1].col+1 {
+			return false
+		}
+	}
+	return true
+}
+
+func slotSeats(slots []seatSlot) []models.Seat {
+	seats := make([]models.Seat, 0, len(slots))
+	for _, slot := range slots {
+		seats = append(seats, slot.seat)
+	}
+	return seats
+}
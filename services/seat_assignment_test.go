@@ -0,0 +1,131 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"airplane_booking_go/models"
+)
+
+// seatsOf → kursi economy kosong dari nomor, nomor berawalan "x" = sudah terisi
+func seatsOf(numbers ...string) []models.Seat {
+	seats := make([]models.Seat, 0, len(numbers))
+	for _, number := range numbers {
+		available := number[0] != 'x'
+		if !available {
+			number = number[1:]
+		}
+		seats = append(seats, models.Seat{Number: number, Class: "economy", IsAvailable: available, Price: 100})
+	}
+	return seats
+}
+
+func seatNumbers(seats []models.Seat) []string {
+	numbers := make([]string, 0, len(seats))
+	for _, seat := range seats {
+		numbers = append(numbers, seat.Number)
+	}
+	return numbers
+}
+
+func TestAssignSeats(t *testing.T) {
+	tests := []struct {
+		name    string
+		seats   []models.Seat
+		class   string
+		count   int
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "single seat takes the first free seat",
+			seats: seatsOf("x1A", "1B", "1C"),
+			class: "economy",
+			count: 1,
+			want:  []string{"1B"},
+		},
+		{
+			name:  "contiguous block preferred over an earlier split row",
+			seats: seatsOf("1A", "x1B", "1C", "2A", "2B", "2C"),
+			class: "economy",
+			count: 2,
+			want:  []string{"2A", "2B"},
+		},
+		{
+			name:  "same row when no contiguous block exists",
+			seats: seatsOf("1A", "x1B", "1C", "2A", "x2B", "x2C"),
+			class: "economy",
+			count: 2,
+			want:  []string{"1A", "1C"},
+		},
+		{
+			name:  "spills over consecutive rows",
+			seats: seatsOf("1A", "x1B", "x1C", "2A", "x2B", "x2C"),
+			class: "economy",
+			count: 2,
+			want:  []string{"1A", "2A"},
+		},
+		{
+			name:  "legacy numbering treated as one long row",
+			seats: seatsOf("E1", "xE2", "E3", "E4"),
+			class: "economy",
+			count: 2,
+			want:  []string{"E3", "E4"},
+		},
+		{
+			name:  "blocked seats and other classes are skipped",
+			seats: append(seatsOf("x1A"), models.Seat{Number: "1B", Class: "economy", IsAvailable: true, Blocked: true}, models.Seat{Number: "1C", Class: "business", IsAvailable: true}, models.Seat{Number: "2A", Class: "economy", IsAvailable: true}),
+			class: "economy",
+			count: 1,
+			want:  []string{"2A"},
+		},
+		{
+			name:    "not enough seats",
+			seats:   seatsOf("1A", "x1B"),
+			class:   "economy",
+			count:   2,
+			wantErr: true,
+		},
+		{
+			name:    "count must be positive",
+			seats:   seatsOf("1A"),
+			class:   "economy",
+			count:   0,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AssignSeats(models.Flight{Seats: tt.seats}, tt.class, tt.count)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("AssignSeats() = %v, want error", seatNumbers(got))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AssignSeats() error = %v", err)
+			}
+			if !reflect.DeepEqual(seatNumbers(got), tt.want) {
+				t.Errorf("AssignSeats() = %v, want %v", seatNumbers(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestAssignSeatsUsesLayoutCoordinates(t *testing.T) {
+	// 3-3 dengan lorong di kolom 4: C (kolom 3) dan D (kolom 5) gak berdampingan
+	seats := []models.Seat{
+		{Number: "1C", Class: "economy", IsAvailable: true, Row: 1, Column: 3},
+		{Number: "1D", Class: "economy", IsAvailable: true, Row: 1, Column: 5},
+		{Number: "1E", Class: "economy", IsAvailable: true, Row: 1, Column: 6},
+	}
+	got, err := AssignSeats(models.Flight{Seats: seats}, "economy", 2)
+	if err != nil {
+		t.Fatalf("AssignSeats() error = %v", err)
+	}
+	if want := []string{"1D", "1E"}; !reflect.DeepEqual(seatNumbers(got), want) {
+		t.Errorf("AssignSeats() = %v, want %v", seatNumbers(got), want)
+	}
+}
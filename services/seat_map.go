@@ -0,0 +1,158 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"airplane_booking_go/models"
+)
+
+// GenerateSeats → bikin daftar kursi dari denah kabin, harga diambil dari Cabin.Price
+func GenerateSeats(layout models.CabinLayout) ([]models.Seat, error) {
+	if len(layout.Cabins) == 0 {
+		return nil, errors.New("cabin layout needs at least one cabin")
+	}
+
+	exitRows := intSet(layout.ExitRows)
+	usedRows := map[int]string{}
+	var seats []models.Seat
+
+	for _, cabin := range layout.Cabins {
+		if cabin.Class == "" {
+			return nil, errors.New("cabin class is required")
+		}
+		if cabin.FirstRow < 1 || cabin.LastRow < cabin.FirstRow {
+			return nil, fmt.Errorf("cabin %s: invalid row range %d-%d", cabin.Class, cabin.FirstRow, cabin.LastRow)
+		}
+
+		columns, err := parseSeatLetters(cabin.SeatLetters)
+		if err != nil {
+			return nil, fmt.Errorf("cabin %s: %v", cabin.Class, err)
+		}
+
+		legroomRows := intSet(cabin.ExtraLegroomRows)
+		skipRows := intSet(cabin.SkipRows)
+		blocked := map[string]bool{}
+		for _, number := range cabin.BlockedSeats {
+			blocked[strings.ToUpper(number)] = true
+		}
+
+		for row := cabin.FirstRow; row <= cabin.LastRow; row++ {
+			if skipRows[row] {
+				continue
+			}
+			if other, ok := usedRows[row]; ok {
+				return nil, fmt.Errorf("row %d used by both %s and %s cabins", row, other, cabin.Class)
+			}
+			usedRows[row] = cabin.Class
+
+			for _, col := range columns {
+				number := fmt.Sprintf("%d%c", row, col.letter)
+				seats = append(seats, models.Seat{
+					Number:       number,
+					Class:        cabin.Class,
+					IsAvailable:  true,
+					Blocked:      blocked[number],
+					Price:        cabin.Price,
+					Row:          row,
+					Column:       col.index,
+					Position:     col.position,
+					ExtraLegroom: legroomRows[row] || exitRows[row],
+					ExitRow:      exitRows[row],
+					OverWing:     layout.WingRows.From > 0 && row >= layout.WingRows.From && row <= layout.WingRows.To,
+				})
+			}
+		}
+	}
+
+	return seats, nil
+}
+
+// DefaultCabinLayout → denah standar dari jumlah kursi per class (business 2-2, economy 3-3),
+// kursi sisa di baris terakhir gak dibuat
+func DefaultCabinLayout(businessCount int, businessPrice float64, economyCount int, economyPrice float64) (models.CabinLayout, map[string]int) {
+	layout := models.CabinLayout{}
+	limits := map[string]int{}
+	nextRow := 1
+
+	add := func(class, letters string, abreast, count int, price float64) {
+		if count <= 0 {
+			return
+		}
+		rows := (count + abreast - 1) / abreast
+		layout.Cabins = append(layout.Cabins, models.Cabin{
+			Class:       class,
+			FirstRow:    nextRow,
+			LastRow:     nextRow + rows - 1,
+			SeatLetters: letters,
+			Price:       price,
+		})
+		limits[class] = count
+		nextRow += rows
+	}
+	add("business", "AC-DF", 4, businessCount, businessPrice)
+	add("economy", "ABC-DEF", 6, economyCount, economyPrice)
+
+	return layout, limits
+}
+
+// LimitSeats → potong kursi per class sesuai limit (dipakai bareng DefaultCabinLayout)
+func LimitSeats(seats []models.Seat, limits map[string]int) []models.Seat {
+	counts := map[string]int{}
+	result := make([]models.Seat, 0, len(seats))
+	for _, seat := range seats {
+		if limit, ok := limits[seat.Class]; ok && counts[seat.Class] >= limit {
+			continue
+		}
+		counts[seat.Class]++
+		result = append(result, seat)
+	}
+	return result
+}
+
+type seatColumn struct {
+	letter   byte
+	index    int
+	position string
+}
+
+// parseSeatLetters → "ABC-DEF" jadi kolom beserta posisi window/aisle/middle
+func parseSeatLetters(letters string) ([]seatColumn, error) {
+	letters = strings.ToUpper(strings.TrimSpace(letters))
+	if letters == "" || strings.HasPrefix(letters, "-") || strings.HasSuffix(letters, "-") || strings.Contains(letters, "--") {
+		return nil, fmt.Errorf("invalid seat letters %q", letters)
+	}
+
+	var columns []seatColumn
+	seen := map[byte]bool{}
+	for i := 0; i < len(letters); i++ {
+		ch := letters[i]
+		if ch == '-' {
+			continue
+		}
+		if ch < 'A' || ch > 'Z' || seen[ch] {
+			return nil, fmt.Errorf("invalid seat letters %q", letters)
+		}
+		seen[ch] = true
+
+		position := models.SeatPositionMiddle
+		switch {
+		case i == 0 || i == len(letters)-1:
+			position = models.SeatPositionWindow
+		case letters[i-1] == '-' || letters[i+1] == '-':
+			position = models.SeatPositionAisle
+		}
+		// index pakai posisi karakter, jadi kursi yang dipisah lorong gak dianggap berdampingan
+		columns = append(columns, seatColumn{letter: ch, index: i + 1, position: position})
+	}
+	return columns, nil
+}
+
+func intSet(values []int) map[int]bool {
+	set := map[int]bool{}
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"airplane_booking_go/models"
+)
+
+func TestParseSeatLetters(t *testing.T) {
+	tests := []struct {
+		letters string
+		want    []seatColumn
+		wantErr bool
+	}{
+		{
+			letters: "ABC-DEF",
+			want: []seatColumn{
+				{'A', 1, models.SeatPositionWindow},
+				{'B', 2, models.SeatPositionMiddle},
+				{'C', 3, models.SeatPositionAisle},
+				{'D', 5, models.SeatPositionAisle},
+				{'E', 6, models.SeatPositionMiddle},
+				{'F', 7, models.SeatPositionWindow},
+			},
+		},
+		{
+			letters: "ac-df",
+			want: []seatColumn{
+				{'A', 1, models.SeatPositionWindow},
+				{'C', 2, models.SeatPositionAisle},
+				{'D', 4, models.SeatPositionAisle},
+				{'F', 5, models.SeatPositionWindow},
+			},
+		},
+		{
+			letters: "A-DEFG-K",
+			want: []seatColumn{
+				{'A', 1, models.SeatPositionWindow},
+				{'D', 3, models.SeatPositionAisle},
+				{'E', 4, models.SeatPositionMiddle},
+				{'F', 5, models.SeatPositionMiddle},
+				{'G', 6, models.SeatPositionAisle},
+				{'K', 8, models.SeatPositionWindow},
+			},
+		},
+		{letters: "", wantErr: true},
+		{letters: "-ABC", wantErr: true},
+		{letters: "ABC-", wantErr: true},
+		{letters: "AB--CD", wantErr: true},
+		{letters: "ABA", wantErr: true},
+		{letters: "A1B", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.letters, func(t *testing.T) {
+			got, err := parseSeatLetters(tt.letters)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseSeatLetters(%q) = %v, want error", tt.letters, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSeatLetters(%q) error = %v", tt.letters, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSeatLetters(%q) = %v, want %v", tt.letters, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateSeats(t *testing.T) {
+	layout := models.CabinLayout{
+		Cabins: []models.Cabin{
+			{Class: "business", FirstRow: 1, LastRow: 1, SeatLetters: "A-C", Price: 500, BlockedSeats: []string{"1c"}},
+			{Class: "economy", FirstRow: 12, LastRow: 14, SeatLetters: "AB", Price: 100, ExtraLegroomRows: []int{12}, SkipRows: []int{13}},
+		},
+		ExitRows: []int{14},
+		WingRows: models.RowRange{From: 14, To: 14},
+	}
+
+	seats, err := GenerateSeats(layout)
+	if err != nil {
+		t.Fatalf("GenerateSeats() error = %v", err)
+	}
+	want := []models.Seat{
+		{Number: "1A", Class: "business", IsAvailable: true, Price: 500, Row: 1, Column: 1, Position: models.SeatPositionWindow},
+		{Number: "1C", Class: "business", IsAvailable: true, Blocked: true, Price: 500, Row: 1, Column: 3, Position: models.SeatPositionWindow},
+		{Number: "12A", Class: "economy", IsAvailable: true, Price: 100, Row: 12, Column: 1, Position: models.SeatPositionWindow, ExtraLegroom: true},
+		{Number: "12B", Class: "economy", IsAvailable: true, Price: 100, Row: 12, Column: 2, Position: models.SeatPositionWindow, ExtraLegroom: true},
+		{Number: "14A", Class: "economy", IsAvailable: true, Price: 100, Row: 14, Column: 1, Position: models.SeatPositionWindow, ExtraLegroom: true, ExitRow: true, OverWing: true},
+		{Number: "14B", Class: "economy", IsAvailable: true, Price: 100, Row: 14, Column: 2, Position: models.SeatPositionWindow, ExtraLegroom: true, ExitRow: true, OverWing: true},
+	}
+	if !reflect.DeepEqual(seats, want) {
+		t.Errorf("GenerateSeats() =\n%+v\nwant\n%+v", seats, want)
+	}
+}
+
+func TestGenerateSeatsInvalidLayout(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout models.CabinLayout
+	}{
+		{"no cabins", models.CabinLayout{}},
+		{"missing class", models.CabinLayout{Cabins: []models.Cabin{{FirstRow: 1, LastRow: 2, SeatLetters: "AB"}}}},
+		{"row range reversed", models.CabinLayout{Cabins: []models.Cabin{{Class: "economy", FirstRow: 5, LastRow: 2, SeatLetters: "AB"}}}},
+		{"row zero", models.CabinLayout{Cabins: []models.Cabin{{Class: "economy", FirstRow: 0, LastRow: 2, SeatLetters: "AB"}}}},
+		{"bad letters", models.CabinLayout{Cabins: []models.Cabin{{Class: "economy", FirstRow: 1, LastRow: 2, SeatLetters: "A--B"}}}},
+		{"overlapping cabins", models.CabinLayout{Cabins: []models.Cabin{
+			{Class: "business", FirstRow: 1, LastRow: 3, SeatLetters: "AB"},
+			{Class: "economy", FirstRow: 3, LastRow: 10, SeatLetters: "AB"},
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if seats, err := GenerateSeats(tt.layout); err == nil {
+				t.Errorf("GenerateSeats() = %d seats, want error", len(seats))
+			}
+		})
+	}
+}
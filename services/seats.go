@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"airplane_booking_go/models"
+)
+
+// ClaimSeats → tandai kursi jadi unavailable, gagal kalau ada kursi yang sudah diambil orang lain
+func ClaimSeats(ctx context.Context, flightColl *mongo.Collection, flightID primitive.ObjectID, seatNumbers []string) error {
+	for _, seatNum := range seatNumbers {
+		result, err := flightColl.UpdateOne(
+			ctx,
+			bson.M{
+				"_id": flightID,
+				"seats": bson.M{
+					"$elemMatch": bson.M{"number": seatNum, "isAvailable": true, "blocked": bson.M{"$ne": true}},
+				},
+			},
+			bson.M{
+				"$set": bson.M{
+					"seats.$.isAvailable": false,
+					"updatedAt":           time.Now(),
+				},
+			},
+		)
+		if err != nil || result.ModifiedCount == 0 {
+			return fmt.Errorf("seat %s just got booked", seatNum)
+		}
+	}
+	return nil
+}
+
+// ReleaseSeats → balikin kursi ke available
+func ReleaseSeats(ctx context.Context, flightColl *mongo.Collection, flightID primitive.ObjectID, seats []models.Seat) error {
+	for _, seat := range seats {
+		_, err := flightColl.UpdateOne(
+			ctx,
+			bson.M{"_id": flightID, "seats.number": seat.Number},
+			bson.M{"$set": bson.M{"seats.$.isAvailable": true, "updatedAt": time.Now()}},
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SelectSeats → ambil kursi dari flight sesuai nomor, cek tersedia, dan hitung total harga
+func SelectSeats(flight models.Flight, seatNumbers []string) ([]models.Seat, float64, error) {
+	var selectedSeats []models.Seat
+	totalPrice := 0.0
+	for _, seatNum := range seatNumbers {
+		found := false
+		for _, seat := range flight.Seats {
+			if seat.Number == seatNum {
+				found = true
+				if !seat.IsBookable() {
+					return nil, 0, fmt.Errorf("seat %s not available", seatNum)
+				}
+				selectedSeats = append(selectedSeats, seat)
+				totalPrice += seat.Price
+				break
+			}
+		}
+		if !found {
+			return nil, 0, fmt.Errorf("seat %s not found", seatNum)
+		}
+	}
+	return selectedSeats, totalPrice, nil
+}
+
+// ReleaseBookingSeats → lepas kursi di semua segmen booking
+func ReleaseBookingSeats(ctx context.Context, flightColl *mongo.Collection, booking models.Booking) error {
+	for _, segment := range booking.AllSegments() {
+		if err := ReleaseSeats(ctx, flightColl, segment.FlightID, segment.Seats); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadBookingFlights → ambil semua flight yang dipakai booking, key = flight id
+func LoadBookingFlights(ctx context.Context, flightColl *mongo.Collection, booking models.Booking) (map[primitive.ObjectID]models.Flight, error) {
+	flights := map[primitive.ObjectID]models.Flight{}
+	for _, segment := range booking.AllSegments() {
+		var flight models.Flight
+		if err := flightColl.FindOne(ctx, bson.M{"_id": segment.FlightID}).Decode(&flight); err != nil {
+			return nil, fmt.Errorf("flight %s not found", segment.FlightID.Hex())
+		}
+		flights[flight.ID] = flight
+	}
+	return flights, nil
+}
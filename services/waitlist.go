@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"airplane_booking_go/models"
+)
+
+// Waitlist → kelola antrian kursi, kursi yang kebuka ditawarkan ke antrian paling depan
+type Waitlist struct {
+	WaitlistCollection *mongo.Collection
+	FlightCollection   *mongo.Collection
+	ClaimWindow        time.Duration
+}
+
+func NewWaitlist(waitlistColl, flightColl *mongo.Collection, claimWindow time.Duration) *Waitlist {
+	return &Waitlist{
+		WaitlistCollection: waitlistColl,
+		FlightCollection:   flightColl,
+		ClaimWindow:        claimWindow,
+	}
+}
+
+// AvailableSeats → kursi kosong di class tertentu
+func AvailableSeats(flight models.Flight, class string) []models.Seat {
+	var seats []models.Seat
+	for _, seat := range flight.Seats {
+		if seat.IsBookable() && seat.Class == class {
+			seats = append(seats, seat)
+		}
+	}
+	return seats
+}
+
+// OfferFreedSeats → tawarkan kursi kosong ke antrian terdepan (FIFO ketat per class).
+// Kursi yang ditawarkan langsung dikunci sampai offerExpiresAt.
+func (w *Waitlist) OfferFreedSeats(ctx context.Context, flightID primitive.ObjectID) (int, error) {
+	offered := 0
+	for {
+		var flight models.Flight
+		if err := w.FlightCollection.FindOne(ctx, bson.M{"_id": flightID}).Decode(&flight); err != nil {
+			return offered, err
+		}
+		if EnsureBookable(flight) != nil {
+			return offered, nil
+		}
+
+		progressed := false
+		for _, class := range waitlistClasses(flight) {
+			var entry models.WaitlistEntry
+			err := w.WaitlistCollection.FindOne(ctx,
+				bson.M{"flightId": flightID, "class": class, "status": "waiting"},
+				options.FindOne().SetSort(bson.D{{Key: "createdAt", Value: 1}}),
+			).Decode(&entry)
+			if err == mongo.ErrNoDocuments {
+				continue
+			}
+			if err != nil {
+				return offered, err
+			}
+
+			// kursi dipilihkan sama seperti auto assign (grup diusahakan berdampingan)
+			seats, err := AssignSeats(flight, class, entry.SeatCount)
+			if err != nil {
+				continue
+			}
+
+			ok, err := w.offer(ctx, entry, seats)
+			if err != nil {
+				return offered, err
+			}
+			if ok {
+				offered++
+				progressed = true
+				break // data flight berubah, ambil ulang
+			}
+		}
+
+		if !progressed {
+			return offered, nil
+		}
+	}
+}
+
+func (w *Waitlist) offer(ctx context.Context, entry models.WaitlistEntry, seats []models.Seat) (bool, error) {
+	session, err := w.WaitlistCollection.Database().Client().StartSession()
+	if err != nil {
+		return false, err
+	}
+	defer session.EndSession(ctx)
+
+	numbers := make([]string, 0, len(seats))
+	for _, seat := range seats {
+		numbers = append(numbers, seat.Number)
+	}
+
+	result, err := session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		if err := ClaimSeats(sessCtx, w.FlightCollection, entry.FlightID, numbers); err != nil {
+			return false, err
+		}
+
+		expiresAt := time.Now().Add(w.ClaimWindow)
+		res, err := w.WaitlistCollection.UpdateOne(sessCtx,
+			bson.M{"_id": entry.ID, "status": "waiting"},
+			bson.M{"$set": bson.M{
+				"status":         "offered",
+				"offeredSeats":   numbers,
+				"offerExpiresAt": expiresAt,
+				"updatedAt":      time.Now(),
+			}},
+		)
+		if err != nil {
+			return false, err
+		}
+		if res.ModifiedCount == 0 {
+			return false, ErrBookingChanged
+		}
+		return true, nil
+	})
+	if err != nil {
+		// kursi keburu diambil / entry berubah → coba lagi di putaran berikutnya
+		log.Printf("waitlist: offer to %s skipped: %v\n", entry.ID.Hex(), err)
+		return false, nil
+	}
+	return result.(bool), nil
+}
+
+// ExpireOffers → tawaran yang gak di-claim dilepas lalu ditawarkan ke antrian berikutnya
+func (w *Waitlist) ExpireOffers(ctx context.Context) (int, error) {
+	cursor, err := w.WaitlistCollection.Find(ctx, bson.M{
+		"status":         "offered",
+		"offerExpiresAt": bson.M{"$lte": time.Now()},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var entries []models.WaitlistEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, entry := range entries {
+		ok, err := w.releaseOffer(ctx, entry, "expired", bson.M{"offerExpiresAt": bson.M{"$lte": time.Now()}})
+		if err != nil {
+			log.Printf("waitlist: expire offer %s: %v\n", entry.ID.Hex(), err)
+			continue
+		}
+		if ok {
+			expired++
+			if _, err := w.OfferFreedSeats(ctx, entry.FlightID); err != nil {
+				log.Printf("waitlist: re-offer flight %s: %v\n", entry.FlightID.Hex(), err)
+			}
+		}
+	}
+	return expired, nil
+}
+
+// Leave → user keluar dari antrian, kalau sedang ditawari kursinya dilepas ke antrian berikutnya
+func (w *Waitlist) Leave(ctx context.Context, entry models.WaitlistEntry) (bool, error) {
+	switch entry.Status {
+	case "waiting":
+		res, err := w.WaitlistCollection.UpdateOne(ctx,
+			bson.M{"_id": entry.ID, "status": "waiting"},
+			bson.M{"$set": bson.M{"status": "cancelled", "updatedAt": time.Now()}},
+		)
+		if err != nil {
+			return false, err
+		}
+		return res.ModifiedCount > 0, nil
+	case "offered":
+		ok, err := w.releaseOffer(ctx, entry, "cancelled", nil)
+		if err != nil || !ok {
+			return ok, err
+		}
+		if _, err := w.OfferFreedSeats(ctx, entry.FlightID); err != nil {
+			log.Printf("waitlist: re-offer flight %s: %v\n", entry.FlightID.Hex(), err)
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func (w *Waitlist) releaseOffer(ctx context.Context, entry models.WaitlistEntry, status string, extraFilter bson.M) (bool, error) {
+	session, err := w.WaitlistCollection.Database().Client().StartSession()
+	if err != nil {
+		return false, err
+	}
+	defer session.EndSession(ctx)
+
+	result, err := session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		filter := bson.M{"_id": entry.ID, "status": "offered"}
+		for k, v := range extraFilter {
+			filter[k] = v
+		}
+		res, err := w.WaitlistCollection.UpdateOne(sessCtx, filter,
+			bson.M{"$set": bson.M{"status": status, "updatedAt": time.Now()}},
+		)
+		if err != nil {
+			return false, err
+		}
+		if res.ModifiedCount == 0 {
+			return false, nil
+		}
+
+		seats := make([]models.Seat, 0, len(entry.OfferedSeats))
+		for _, number := range entry.OfferedSeats {
+			seats = append(seats, models.Seat{Number: number})
+		}
+		if err := ReleaseSeats(sessCtx, w.FlightCollection, entry.FlightID, seats); err != nil {
+			return false, err
+		}
+		return true, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.(bool), nil
+}
+
+// OfferFreedSeatsForBooking → dipanggil setelah kursi booking dilepas (cancel / hold expired)
+func (w *Waitlist) OfferFreedSeatsForBooking(ctx context.Context, booking models.Booking) {
+	for _, segment := range booking.AllSegments() {
+		if _, err := w.OfferFreedSeats(ctx, segment.FlightID); err != nil {
+			log.Printf("waitlist: offer freed seats on flight %s: %v\n", segment.FlightID.Hex(), err)
+		}
+	}
+}
+
+func waitlistClasses(flight models.Flight) []string {
+	seen := map[string]bool{}
+	var classes []string
+	for _, seat := range flight.Seats {
+		if !seen[seat.Class] {
+			seen[seat.Class] = true
+			classes = append(classes, seat.Class)
+		}
+	}
+	return classes
+}
+
+// ResetOffers → tawaran yang masih jalan di flight ini dibalikin ke waiting (posisi antrian tetap),
+// dipakai waktu seat map diganti. Kursinya gak dilepas karena seat map lama sudah gak dipakai.
+func (w *Waitlist) ResetOffers(ctx context.Context, flightID primitive.ObjectID) error {
+	_, err := w.WaitlistCollection.UpdateMany(ctx,
+		bson.M{"flightId": flightID, "status": "offered"},
+		bson.M{
+			"$set":   bson.M{"status": "waiting", "updatedAt": time.Now()},
+			"$unset": bson.M{"offeredSeats": "", "offerExpiresAt": ""},
+		},
+	)
+	return err
+}
+
+// CancelFlightEntries → flight dibatalkan, semua antrian di flight itu ikut di-cancel
+func (w *Waitlist) CancelFlightEntries(ctx context.Context, flightID primitive.ObjectID) (int64, error) {
+	result, err := w.WaitlistCollection.UpdateMany(ctx,
+		bson.M{"flightId": flightID, "status": bson.M{"$in": []string{"waiting", "offered"}}},
+		bson.M{
+			"$set":   bson.M{"status": "cancelled", "updatedAt": time.Now()},
+			"$unset": bson.M{"offerExpiresAt": ""},
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
@@ -0,0 +1,338 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"airplane_booking_go/events"
+	"airplane_booking_go/models"
+)
+
+const (
+	webhookLease       = 2 * time.Minute
+	webhookBaseBackoff = 30 * time.Second
+	webhookMaxBackoff  = 6 * time.Hour
+	webhookHistoryMax  = 20 // log percobaan per delivery dibatasi biar dokumen gak membengkak
+)
+
+var ErrDeliveryNotRetryable = errors.New("only dead deliveries can be retried")
+
+// WebhookEnvelope → body yang diterima subscriber. ID = id domain event, sama untuk setiap retry.
+type WebhookEnvelope struct {
+	ID        string                 `json:"id"`
+	Type      string                 `json:"type"`
+	CreatedAt time.Time              `json:"createdAt"`
+	Data      interface{}            `json:"data"`              // booking / flight setelah perubahan
+	Details   map[string]interface{} `json:"details,omitempty"` // konteks perubahan (from, to, actor, reason, ...)
+}
+
+// WebhookDispatcher → consumer outbox yang fan-out event ke subscription cocok lewat antrian delivery persisten.
+// Kirim + retry jalan di background, terpisah dari request yang bikin event-nya.
+type WebhookDispatcher struct {
+	SubscriptionCollection *mongo.Collection
+	DeliveryCollection     *mongo.Collection
+	BookingCollection      *mongo.Collection
+	FlightCollection       *mongo.Collection
+	Client                 *http.Client
+	MaxAttempts            int
+	Interval               time.Duration
+}
+
+func NewWebhookDispatcher(subscriptionColl, deliveryColl, bookingColl, flightColl *mongo.Collection, timeout time.Duration, maxAttempts int, interval time.Duration) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		SubscriptionCollection: subscriptionColl,
+		DeliveryCollection:     deliveryColl,
+		BookingCollection:      bookingColl,
+		FlightCollection:       flightColl,
+		Client:                 &http.Client{Timeout: timeout},
+		MaxAttempts:            maxAttempts,
+		Interval:               interval,
+	}
+}
+
+// HandleEvent → consumer outbox: bikin delivery per subscription aktif yang berlangganan event ini.
+// Aman dipanggil ulang untuk event yang sama (unique eventId + subscriptionId).
+func (d *WebhookDispatcher) HandleEvent(ctx context.Context, event events.Event) error {
+	if !IsWebhookEvent(event.Type) {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := d.SubscriptionCollection.Find(ctx, bson.M{
+		"active": true,
+		"events": bson.M{"$in": []string{event.Type, "*"}},
+	})
+	if err != nil {
+		return err
+	}
+	var subscriptions []models.WebhookSubscription
+	if err := cursor.All(ctx, &subscriptions); err != nil {
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	data, err := d.aggregate(ctx, event)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(WebhookEnvelope{
+		ID:        event.ID,
+		Type:      event.Type,
+		CreatedAt: event.OccurredAt.UTC(),
+		Data:      data,
+		Details:   event.Data,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal payload: %v", err)
+	}
+
+	now := time.Now()
+	docs := make([]interface{}, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		docs = append(docs, models.WebhookDelivery{
+			ID:             primitive.NewObjectID(),
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			Event:          event.Type,
+			URL:            subscription.URL,
+			Payload:        string(payload),
+			Status:         "pending",
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		})
+	}
+	_, err = d.DeliveryCollection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if mongo.IsDuplicateKeyError(err) {
+		// sebagian sudah dibuat di percobaan sebelumnya
+		return nil
+	}
+	return err
+}
+
+// aggregate → state terbaru booking / flight (flight tanpa seat map, terlalu besar buat payload)
+func (d *WebhookDispatcher) aggregate(ctx context.Context, event events.Event) (interface{}, error) {
+	id, err := primitive.ObjectIDFromHex(event.AggregateID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch event.AggregateType {
+	case events.AggregateBooking:
+		var booking models.Booking
+		if err := d.BookingCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&booking); err != nil {
+			return nil, fmt.Errorf("fetch booking %s: %v", event.AggregateID, err)
+		}
+		return booking, nil
+	case events.AggregateFlight:
+		var flight bson.M
+		err := d.FlightCollection.FindOne(ctx, bson.M{"_id": id},
+			options.FindOne().SetProjection(bson.M{"seats": 0, "cabinLayout": 0})).Decode(&flight)
+		if err != nil {
+			return nil, fmt.Errorf("fetch flight %s: %v", event.AggregateID, err)
+		}
+		return flight, nil
+	}
+	return nil, fmt.Errorf("unknown aggregate %s", event.AggregateType)
+}
+
+// Start → proses antrian delivery tiap Interval sampai ctx di-cancel
+func (d *WebhookDispatcher) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(d.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				delivered, failed, err := d.ProcessDue(ctx)
+				if err != nil {
+					log.Println("webhook worker:", err)
+				}
+				if delivered > 0 || failed > 0 {
+					log.Printf("webhook worker: delivered %d, failed %d\n", delivered, failed)
+				}
+			}
+		}
+	}()
+}
+
+// ProcessDue → kirim semua delivery yang jatuh tempo, return jumlah sukses dan gagal (percobaan ini)
+func (d *WebhookDispatcher) ProcessDue(ctx context.Context) (int, int, error) {
+	delivered, failed := 0, 0
+	for {
+		delivery, err := d.claimNext(ctx)
+		if err == mongo.ErrNoDocuments {
+			return delivered, failed, nil
+		}
+		if err != nil {
+			return delivered, failed, err
+		}
+
+		attempt := d.send(ctx, delivery)
+		if attempt.Error != "" {
+			failed++
+		} else {
+			delivered++
+		}
+		d.record(ctx, delivery, attempt)
+	}
+}
+
+// claimNext → ambil satu delivery pending yang jatuh tempo, dikunci pakai lease
+func (d *WebhookDispatcher) claimNext(ctx context.Context) (models.WebhookDelivery, error) {
+	now := time.Now()
+	var delivery models.WebhookDelivery
+	err := d.DeliveryCollection.FindOneAndUpdate(ctx,
+		bson.M{"status": "pending", "nextAttemptAt": bson.M{"$lte": now}},
+		bson.M{
+			"$set": bson.M{"nextAttemptAt": now.Add(webhookLease), "updatedAt": now},
+			"$inc": bson.M{"attempts": 1},
+		},
+		options.FindOneAndUpdate().
+			SetSort(bson.D{{Key: "nextAttemptAt", Value: 1}}).
+			SetReturnDocument(options.After),
+	).Decode(&delivery)
+	return delivery, err
+}
+
+// send → POST payload ke subscriber, sukses kalau dibalas 2xx
+func (d *WebhookDispatcher) send(ctx context.Context, delivery models.WebhookDelivery) models.WebhookAttempt {
+	started := time.Now()
+	attempt := models.WebhookAttempt{At: started}
+
+	var subscription models.WebhookSubscription
+	if err := d.SubscriptionCollection.FindOne(ctx, bson.M{"_id": delivery.SubscriptionID}).Decode(&subscription); err != nil {
+		attempt.Error = "subscription not found"
+		return attempt
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewBufferString(delivery.Payload))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	timestamp := started.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Id", delivery.EventID)
+	req.Header.Set("X-Webhook-Event", delivery.Event)
+	req.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(timestamp, 10))
+	req.Header.Set("X-Webhook-Signature", "v1="+SignWebhook(subscription.Secret, timestamp, []byte(delivery.Payload)))
+
+	resp, err := d.Client.Do(req)
+	attempt.DurationMs = time.Since(started).Milliseconds()
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	attempt.StatusCode = resp.StatusCode
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		attempt.Error = fmt.Sprintf("unexpected status %d", resp.StatusCode)
+	}
+	return attempt
+}
+
+// record → simpan hasil percobaan: delivered, jadwal ulang (exponential backoff), atau dead setelah MaxAttempts
+func (d *WebhookDispatcher) record(ctx context.Context, delivery models.WebhookDelivery, attempt models.WebhookAttempt) {
+	now := time.Now()
+
+	set := bson.M{"updatedAt": now}
+	unset := bson.M{}
+	switch {
+	case attempt.Error == "":
+		set["status"] = "delivered"
+		set["deliveredAt"] = now
+		unset["lastError"] = ""
+	case delivery.Attempts >= d.MaxAttempts:
+		set["status"] = "dead"
+		set["lastError"] = attempt.Error
+	default:
+		set["nextAttemptAt"] = now.Add(Backoff(delivery.Attempts, webhookBaseBackoff, webhookMaxBackoff))
+		set["lastError"] = attempt.Error
+	}
+
+	update := bson.M{
+		"$set": set,
+		"$push": bson.M{"history": bson.M{
+			"$each":  []models.WebhookAttempt{attempt},
+			"$slice": -webhookHistoryMax,
+		}},
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	if _, err := d.DeliveryCollection.UpdateOne(ctx, bson.M{"_id": delivery.ID}, update); err != nil {
+		log.Printf("webhook: record delivery %s: %v\n", delivery.ID.Hex(), err)
+	}
+}
+
+// Redeliver → kirim ulang delivery dari dead letter, jatah percobaan di-reset
+func (d *WebhookDispatcher) Redeliver(ctx context.Context, deliveryID primitive.ObjectID) error {
+	result, err := d.DeliveryCollection.UpdateOne(ctx,
+		bson.M{"_id": deliveryID, "status": "dead"},
+		bson.M{"$set": bson.M{
+			"status":        "pending",
+			"attempts":      0,
+			"nextAttemptAt": time.Now(),
+			"updatedAt":     time.Now(),
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrDeliveryNotRetryable
+	}
+	return nil
+}
+
+// SignWebhook → hex HMAC-SHA256 dari "<timestamp>.<payload>" pakai secret subscription.
+// Subscriber verifikasi dengan menghitung ulang dari header X-Webhook-Timestamp + body mentah.
+func SignWebhook(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookEvents → semua event yang boleh di-subscribe
+var WebhookEvents = events.All
+
+// IsWebhookEvent → nama event dikenal (atau "*")
+func IsWebhookEvent(event string) bool {
+	if event == "*" {
+		return true
+	}
+	for _, e := range WebhookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
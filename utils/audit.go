@@ -0,0 +1,15 @@
+package utils
+
+import "github.com/gin-gonic/gin"
+
+const auditTargetKey = "auditTarget"
+
+// SetAuditTarget → id entity yang baru dibuat handler, biar audit middleware bisa snapshot hasilnya
+func SetAuditTarget(c *gin.Context, id string) {
+	c.Set(auditTargetKey, id)
+}
+
+// GetAuditTarget → id yang di-set lewat SetAuditTarget
+func GetAuditTarget(c *gin.Context) string {
+	return c.GetString(auditTargetKey)
+}
@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetUserID → ambil userId dari context (di-set AuthMiddleware) sebagai ObjectID
+func GetUserID(c *gin.Context) (primitive.ObjectID, error) {
+	value, exists := c.Get("userId")
+	if !exists {
+		return primitive.NilObjectID, errors.New("unauthorized")
+	}
+
+	switch id := value.(type) {
+	case primitive.ObjectID:
+		return id, nil
+	case string:
+		return primitive.ObjectIDFromHex(id)
+	default:
+		return primitive.NilObjectID, errors.New("invalid user id")
+	}
+}
@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+// tanpa I, O, 0, 1 biar gak ketuker waktu dibacakan lewat telepon
+const recordLocatorAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// GenerateRecordLocator → kode booking (PNR) 6 karakter, ex: "K7QX2M"
+func GenerateRecordLocator() (string, error) {
+	code := make([]byte, 6)
+	max := big.NewInt(int64(len(recordLocatorAlphabet)))
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = recordLocatorAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
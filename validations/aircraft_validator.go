@@ -0,0 +1,15 @@
+package validations
+
+import "airplane_booking_go/models"
+
+type AircraftRequest struct {
+	TypeCode     string             `json:"typeCode" binding:"required,alphanum,min=3,max=4"`
+	Name         string             `json:"name" binding:"required"`
+	Manufacturer string             `json:"manufacturer"`
+	Layout       models.CabinLayout `json:"layout" binding:"required"`
+}
+
+type ChangeAircraftRequest struct {
+	AircraftType string             `json:"aircraftType" binding:"required"`
+	Fares        map[string]float64 `json:"fares"` // class → harga, kosong = pakai harga lama per class
+}
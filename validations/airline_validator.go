@@ -0,0 +1,16 @@
+package validations
+
+type AirlineRequest struct {
+	IATA            string                 `json:"iata" binding:"required,alphanum,len=2"` // prefix nomor penerbangan
+	ICAO            string                 `json:"icao" binding:"omitempty,alpha,len=3"`
+	Name            string                 `json:"name" binding:"required"`
+	LogoURL         string                 `json:"logoUrl" binding:"omitempty,url"`
+	BaggagePolicies []BaggagePolicyRequest `json:"baggagePolicies" binding:"omitempty,dive"`
+}
+
+type BaggagePolicyRequest struct {
+	Class           string  `json:"class" binding:"required,oneof=economy business first"`
+	CabinKg         float64 `json:"cabinKg" binding:"min=0"`
+	CheckedPieces   int     `json:"checkedPieces" binding:"min=0"`
+	CheckedKgPerBag float64 `json:"checkedKgPerBag" binding:"min=0"`
+}
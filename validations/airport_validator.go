@@ -0,0 +1,14 @@
+package validations
+
+type AirportRequest struct {
+	Code                 string  `json:"code" binding:"required,alpha,len=3"` // IATA
+	ICAO                 string  `json:"icao" binding:"omitempty,alphanum,len=4"`
+	Name                 string  `json:"name" binding:"required"`
+	City                 string  `json:"city" binding:"required"`
+	Country              string  `json:"country" binding:"required"`
+	TimeZone             string  `json:"timeZone" binding:"required"` // IANA, ex: "Asia/Jakarta"
+	Latitude             float64 `json:"latitude" binding:"min=-90,max=90"`
+	Longitude            float64 `json:"longitude" binding:"min=-180,max=180"`
+	Type                 string  `json:"type"`
+	MinConnectionMinutes int     `json:"minConnectionMinutes" binding:"omitempty,min=0,max=1440"` // 0 = default minConnectionTime
+}
@@ -7,10 +7,66 @@ import (
 
 type CreateBookingRequest struct {
 	FlightID    string   `json:"flightId" binding:"required"`
-	SeatNumbers []string `json:"seatNumbers" binding:"required"`
+	SeatNumbers []string `json:"seatNumbers" binding:"required_without=Class"` // kosong → kursi dipilihkan server sesuai class
+	Class       string   `json:"class" binding:"omitempty,oneof=economy business first"`
+	Passengers  []PassengerRequest `json:"passengers" binding:"required,min=1,dive"` // passengers[i] duduk di seatNumbers[i]
+	Hold        bool     `json:"hold"` // true → kursi di-hold dulu, konfirmasi belakangan
 }
 
 type GetUserBookingsRequest struct {
 	Page  int `form:"page,default=1"`
 	Limit int `form:"limit,default=10"`
-}
\ No newline at end of file
+}
+
+type PayBookingRequest struct {
+	PaymentToken string `json:"paymentToken" binding:"required"`
+}
+
+// status yang bisa di-set manual sama admin, sisanya lewat flow booking/payment
+type UpdateBookingStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=ticketed checked-in flown"`
+	Reason string `json:"reason" binding:"required"`
+}
+
+type LookupBookingRequest struct {
+	RecordLocator string `form:"recordLocator" binding:"required,len=6,alphanum"`
+	LastName      string `form:"lastName" binding:"required"`
+}
+
+type SeatSwapRequest struct {
+	From string `json:"from" binding:"required"`
+	To   string `json:"to" binding:"required,nefield=From"`
+}
+
+type ChangeSeatsRequest struct {
+	Changes      []SeatSwapRequest `json:"changes" binding:"required,min=1,dive"`
+	PaymentToken string            `json:"paymentToken"` // wajib kalau kursi baru lebih mahal
+}
+
+type ChangeFlightRequest struct {
+	FlightID     string   `json:"flightId" binding:"required"`
+	SeatNumbers  []string `json:"seatNumbers" binding:"required,min=1"` // urutannya sama dengan passengers
+	PaymentToken string   `json:"paymentToken"`                         // wajib kalau ada selisih harga / change fee
+}
+
+type ItinerarySegmentRequest struct {
+	FlightID    string   `json:"flightId" binding:"required"`
+	SeatNumbers []string `json:"seatNumbers" binding:"required,min=1"` // seatNumbers[i] untuk passengers[i]
+}
+
+// CreateItineraryBookingRequest → round-trip / connecting flight dalam satu booking
+type CreateItineraryBookingRequest struct {
+	Segments   []ItinerarySegmentRequest `json:"segments" binding:"required,min=1,max=4,dive"`
+	Passengers []PassengerRequest        `json:"passengers" binding:"required,min=1,dive"`
+	Hold       bool                      `json:"hold"`
+}
+
+type JoinWaitlistRequest struct {
+	FlightID  string `json:"flightId" binding:"required"`
+	Class     string `json:"class" binding:"required,oneof=economy business first"`
+	SeatCount int    `json:"seatCount" binding:"required,min=1,max=9"`
+}
+
+type ClaimWaitlistRequest struct {
+	Passengers []PassengerRequest `json:"passengers" binding:"required,min=1,dive"` // passengers[i] duduk di offeredSeats[i]
+}
@@ -6,22 +6,29 @@ import (
 )
 
 type SeatConfig struct {
-	Count int     `json:"count" binding:"required"`
-	Price float64 `json:"price" binding:"required"`
+	Count int     `json:"count" binding:"omitempty,min=0"`
+	Price float64 `json:"price" binding:"omitempty,min=0"`
 }
 
 type CreateFlightRequest struct {
-	Airline       string    `json:"airline" binding:"required"`
+	Airline       string    `json:"airline" binding:"required,min=2,max=3"` // IATA / ICAO maskapai, harus ada di collection airlines
 	FlightNumber  string    `json:"flightNumber" binding:"required"`
-	Departure     models.Airport `json:"departure" binding:"required"`
-	Arrival       models.Airport `json:"arrival" binding:"required"`
-	DepartureTime time.Time `json:"departureTime" binding:"required"`
-	ArrivalTime   time.Time `json:"arrivalTime" binding:"required"`
-	Duration      int       `json:"duration" binding:"required"`
+	Departure     string    `json:"departure" binding:"required,len=3"` // IATA code, harus ada di collection airports
+	Arrival       string    `json:"arrival" binding:"required,len=3"`
+	// DepartureTime / ArrivalTime → RFC3339, atau jam lokal bandara tanpa offset ("2006-01-02T15:04").
+	// Disimpan UTC, duration dihitung dari selisihnya.
+	DepartureTime string    `json:"departureTime" binding:"required"`
+	ArrivalTime   string    `json:"arrivalTime" binding:"required"`
+	// SeatConfig → cara simpel: jumlah + harga per class, denahnya pakai layout default
 	SeatConfig    struct {
 		Business SeatConfig `json:"business"`
 		Economy  SeatConfig `json:"economy"`
-	} `json:"seatConfig" binding:"required"`
+	} `json:"seatConfig"`
+	// Layout → denah kabin lengkap (baris, huruf kursi, exit row, dll), kalau diisi SeatConfig diabaikan
+	Layout        *models.CabinLayout `json:"layout"`
+	// AircraftType → pakai template kabin dari aircraft registry, harga per class dari Fares
+	AircraftType  string             `json:"aircraftType"`
+	Fares         map[string]float64 `json:"fares"`
 }
 
 
@@ -30,20 +37,50 @@ type SearchFlightRequest struct {
 	To       string  `form:"to" binding:"omitempty,len=3"`
 	Date     string  `form:"date" binding:"omitempty,datetime=2006-01-02"`
 	Airline  string  `form:"airline" binding:"omitempty"`
+	Carrier  string  `form:"carrier" binding:"omitempty,alphanum,len=2"` // IATA maskapai, exact match
 	MinPrice float64 `form:"minPrice" binding:"omitempty"`
 	MaxPrice float64 `form:"maxPrice" binding:"omitempty"`
 	Class    string  `form:"class" binding:"omitempty,oneof=economy business first"`
 	Page     int     `form:"page,default=1"`
 	Limit    int     `form:"limit,default=10"`
 }
+// SearchItineraryRequest → cari perjalanan asal → tujuan, termasuk yang transit
+type SearchItineraryRequest struct {
+	From           string `form:"from" binding:"required,len=3"`
+	To             string `form:"to" binding:"required,len=3,nefield=From"`
+	Date           string `form:"date" binding:"required,datetime=2006-01-02"` // tanggal lokal bandara asal
+	Passengers     int    `form:"passengers,default=1" binding:"min=1,max=9"`
+	Class          string `form:"class" binding:"omitempty,oneof=economy business first"`
+	MaxConnections int    `form:"maxConnections,default=2" binding:"min=0,max=2"`
+	MaxTravelHours int    `form:"maxTravelHours" binding:"omitempty,min=1,max=72"` // default maxTravelTime
+	SortBy         string `form:"sortBy,default=price" binding:"oneof=price duration"`
+	Limit          int    `form:"limit,default=20" binding:"min=1,max=50"`
+}
+
 type UpdateFlight struct {
-	Airline       string         `json:"airline" binding:"required"`
+	Airline       string         `json:"airline" binding:"required,min=2,max=3"` // IATA / ICAO maskapai
 	FlightNumber  string         `json:"flightNumber" binding:"required"`
-	Departure     models.Airport `json:"departure" binding:"required"`
-	Arrival       models.Airport `json:"arrival" binding:"required"`
-	DepartureTime time.Time      `json:"departureTime" binding:"required"`
-	ArrivalTime   time.Time      `json:"arrivalTime" binding:"required"`
-	Duration      int            `json:"duration" binding:"required"`
+	Departure     string         `json:"departure" binding:"required,len=3"` // IATA code
+	Arrival       string         `json:"arrival" binding:"required,len=3"`
+	DepartureTime string         `json:"departureTime" binding:"required"` // sama dengan CreateFlightRequest
+	ArrivalTime   string         `json:"arrivalTime" binding:"required"`
 	Price         float64        `json:"price"`
 	Seats         []models.Seat  `json:"seats" binding:"required"`
-}
\ No newline at end of file
+}
+// UpdateFlightStatusRequest → cancelled gak lewat sini, pakai POST /flights/:id/cancel biar booking-nya di-reaccommodate
+type UpdateFlightStatusRequest struct {
+	Status                 string     `json:"status" binding:"required,oneof=scheduled boarding delayed departed arrived diverted"`
+	Reason                 string     `json:"reason"`
+	EstimatedDepartureTime *time.Time `json:"estimatedDepartureTime"`
+	EstimatedArrivalTime   *time.Time `json:"estimatedArrivalTime"`
+	ActualDepartureTime    *time.Time `json:"actualDepartureTime"`
+	ActualArrivalTime      *time.Time `json:"actualArrivalTime"`
+}
+
+type CancelFlightRequest struct {
+	Reason string `json:"reason" binding:"required"`
+	// Policy → rebook: pindah ke flight berikutnya di rute yang sama, kalau gak ada baru refund penuh.
+	// refund: semua booking langsung di-cancel + refund penuh.
+	Policy      string `json:"policy" binding:"omitempty,oneof=rebook refund"`
+	WindowHours int    `json:"windowHours" binding:"omitempty,min=1,max=720"` // default reaccommodationWindow
+}
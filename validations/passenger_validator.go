@@ -0,0 +1,83 @@
+package validations
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"airplane_booking_go/models"
+)
+
+type TravelDocumentRequest struct {
+	Type           string `json:"type" binding:"required,oneof=passport national_id"`
+	Number         string `json:"number" binding:"required,alphanum,min=5,max=20"`
+	IssuingCountry string `json:"issuingCountry" binding:"required,len=2,alpha"`
+	ExpiryDate     string `json:"expiryDate" binding:"required,datetime=2006-01-02"`
+}
+
+type PassengerRequest struct {
+	FirstName   string                `json:"firstName" binding:"required,max=50"`
+	LastName    string                `json:"lastName" binding:"required,max=50"`
+	Gender      string                `json:"gender" binding:"required,oneof=male female"`
+	DateOfBirth string                `json:"dateOfBirth" binding:"required,datetime=2006-01-02"`
+	Nationality string                `json:"nationality" binding:"required,len=2,alpha"`
+	Document    TravelDocumentRequest `json:"document" binding:"required"`
+}
+
+// ValidatePassengers → cek penumpang sesuai kursi (urutan passengers = urutan seatNumbers) lalu convert ke model.
+// seatNumbers kosong → kursi dipilihkan server nanti, SeatNumber diisi belakangan.
+func ValidatePassengers(passengers []PassengerRequest, seatNumbers []string) ([]models.Passenger, error) {
+	autoAssign := len(seatNumbers) == 0
+	if !autoAssign && len(passengers) != len(seatNumbers) {
+		return nil, fmt.Errorf("got %d passengers for %d seats, each seat needs exactly one passenger", len(passengers), len(seatNumbers))
+	}
+
+	today := time.Now().Truncate(24 * time.Hour)
+	seenDocs := map[string]bool{}
+	seenSeats := map[string]bool{}
+	result := make([]models.Passenger, 0, len(passengers))
+
+	for i, p := range passengers {
+		dob, _ := time.Parse("2006-01-02", p.DateOfBirth)
+		if !dob.Before(today) {
+			return nil, fmt.Errorf("passenger %d: dateOfBirth must be in the past", i+1)
+		}
+
+		expiry, _ := time.Parse("2006-01-02", p.Document.ExpiryDate)
+		if expiry.Before(today) {
+			return nil, fmt.Errorf("passenger %d: travel document has expired", i+1)
+		}
+
+		docKey := strings.ToUpper(p.Document.IssuingCountry + p.Document.Number)
+		if seenDocs[docKey] {
+			return nil, fmt.Errorf("passenger %d: duplicate travel document %s", i+1, p.Document.Number)
+		}
+		seenDocs[docKey] = true
+
+		seatNumber := ""
+		if !autoAssign {
+			seatNumber = seatNumbers[i]
+			if seenSeats[seatNumber] {
+				return nil, fmt.Errorf("seat %s selected more than once", seatNumber)
+			}
+			seenSeats[seatNumber] = true
+		}
+
+		result = append(result, models.Passenger{
+			FirstName:   strings.TrimSpace(p.FirstName),
+			LastName:    strings.TrimSpace(p.LastName),
+			Gender:      p.Gender,
+			DateOfBirth: dob,
+			Nationality: strings.ToUpper(p.Nationality),
+			Document: models.TravelDocument{
+				Type:           p.Document.Type,
+				Number:         strings.ToUpper(p.Document.Number),
+				IssuingCountry: strings.ToUpper(p.Document.IssuingCountry),
+				ExpiryDate:     expiry,
+			},
+			SeatNumber: seatNumber,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,21 @@
+package validations
+
+type ScheduleRequest struct {
+	Airline       string             `json:"airline" binding:"required,min=2,max=3"` // IATA / ICAO maskapai
+	FlightNumber  string             `json:"flightNumber" binding:"required"`
+	Departure     string             `json:"departure" binding:"required,len=3"` // IATA code, harus ada di collection airports
+	Arrival       string             `json:"arrival" binding:"required,len=3"`
+	DepartureTime string             `json:"departureTime" binding:"required,datetime=15:04"` // jam lokal
+	Duration      int                `json:"duration" binding:"required,min=1"`               // menit
+	TimeZone      string             `json:"timeZone"`                                        // kosong = timeZone bandara asal
+	DaysOfWeek    []int              `json:"daysOfWeek" binding:"required,min=1,dive,min=1,max=7"`
+	ValidFrom     string             `json:"validFrom" binding:"required,datetime=2006-01-02"`
+	ValidTo       string             `json:"validTo" binding:"required,datetime=2006-01-02"`
+	AircraftType  string             `json:"aircraftType" binding:"required"`
+	Fares         map[string]float64 `json:"fares" binding:"required"`
+	Active        *bool              `json:"active"` // default true
+}
+
+type GenerateScheduleRequest struct {
+	Days int `form:"days" binding:"omitempty,min=1,max=366"` // default pakai scheduleHorizon
+}
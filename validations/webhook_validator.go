@@ -0,0 +1,9 @@
+package validations
+
+type WebhookSubscriptionRequest struct {
+	URL         string   `json:"url" binding:"required,url"`
+	Events      []string `json:"events" binding:"required,min=1"`   // ex: ["booking.created"], "*" = semua event
+	Secret      string   `json:"secret" binding:"omitempty,min=16"` // kosong = di-generate (waktu create) / tetap (waktu update)
+	Description string   `json:"description"`
+	Active      *bool    `json:"active"` // default true
+}